@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/meir/izu/internal/manifest"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+	"github.com/urfave/cli/v2"
+)
+
+// buildCommand generates every target of a manifest from a single config
+var buildCommand = &cli.Command{
+	Name:  "build",
+	Usage: "Generate all the targets listed in a manifest",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "manifest",
+			Aliases: []string{"m"},
+			Usage:   "Path to the manifest file",
+			Value:   "izu.json",
+		},
+		&cli.StringSliceFlag{
+			Name:    "tag",
+			Aliases: []string{"t"},
+			Usage:   "Only build the targets with this tag, can be given multiple times",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		m, err := manifest.Load(c.String("manifest"))
+		if err != nil {
+			slog.Error("Failed to load manifest: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		content, err := os.ReadFile(m.Config)
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		hotkeys, err := parser.Parse(content)
+		if err != nil {
+			slog.Error("Failed to parse hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		targets := m.Select(c.StringSlice("tag"))
+		if len(targets) == 0 {
+			slog.Warn("No targets selected", "tags", c.StringSlice("tag"))
+		}
+
+		failed := 0
+		for _, target := range targets {
+			if err := buildTarget(c, target, hotkeys); err != nil {
+				slog.Error("Failed to build target", "target", target.Name, "error", err.Error())
+				failed++
+				continue
+			}
+			slog.Info("Built target", "target", target.Name)
+		}
+
+		slog.Info("Build finished", "targets", len(targets), "failed", failed)
+		if failed > 0 {
+			return cli.Exit("", 1)
+		}
+		return nil
+	},
+}
+
+// buildTarget formats the hotkeys for the target, writes them and runs the reload command if the output changed
+func buildTarget(c *cli.Context, target *manifest.Target, hotkeys []*izu.Hotkey) error {
+	formatter, err := newFormatterWithOptions(target.Formatter, target.Options)
+	if err != nil {
+		return fmt.Errorf("failed to create formatter: %w", err)
+	}
+
+	lines, err := formatter.Format(hotkeys)
+	if err != nil {
+		return fmt.Errorf("failed to format hotkeys: %w", err)
+	}
+
+	changed, err := writeOutput(lines, target.Output, target.Inject, target.Backup)
+	if err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if changed {
+		// like watch, targets without a reload command use the reload command of the formatter
+		command := target.Reload
+		if command == "" {
+			command = formatter.Reload()
+		}
+		if err := reload(c.Context, command); err != nil {
+			return fmt.Errorf("failed to reload: %w", err)
+		}
+	}
+	return nil
+}
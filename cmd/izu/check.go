@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/meir/izu/internal/check"
+	"github.com/urfave/cli/v2"
+)
+
+// checkCommand validates the config against formatters without writing any output
+var checkCommand = &cli.Command{
+	Name:  "check",
+	Usage: "Validate the config for every formatter without writing any output",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Usage:    "Path to the configuration file",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:    "formatter",
+			Aliases: []string{"f"},
+			Usage:   "Formatter to check the config with, can be given multiple times (default: all embedded formatters)",
+		},
+		&cli.BoolFlag{
+			Name:  "strict",
+			Usage: "Treat warnings as errors",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		path := c.String("config")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		formatters, err := newFormatters(c.StringSlice("formatter"))
+		if err != nil {
+			slog.Error("Failed to create formatter: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		issues := check.Check(content, formatters)
+		for _, issue := range issues {
+			fmt.Printf("%s: %s\n", path, issue.String())
+		}
+
+		slog.Info("Checked config", "formatters", len(formatters), "issues", len(issues))
+		if check.HasErrors(issues) || (c.Bool("strict") && len(issues) > 0) {
+			return cli.Exit("", 1)
+		}
+		return nil
+	},
+}
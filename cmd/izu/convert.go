@@ -0,0 +1,83 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/meir/izu/internal/convert"
+	"github.com/meir/izu/internal/importer"
+	"github.com/urfave/cli/v2"
+)
+
+// convertCommand converts the config of one hotkey daemon directly into the config of another
+var convertCommand = &cli.Command{
+	Name:      "convert",
+	Usage:     "Convert the config of one hotkey daemon into the config of another",
+	ArgsUsage: "<config file>",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "from",
+			Usage:    "System of the config to convert (sway, i3, hyprland, niri)",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "to",
+			Usage:    "Formatter to convert the config to, or 'auto' to detect it from the running session",
+			Required: true,
+		},
+	}, outputFlags...),
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			slog.Error("Expected exactly one config file to convert")
+			return cli.Exit("", 1)
+		}
+
+		content, err := os.ReadFile(c.Args().First())
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		imp, err := importer.Get(c.String("from"))
+		if err != nil {
+			slog.Error("Failed to create importer: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		hotkeys, warnings, err := imp.Import(content)
+		if err != nil {
+			slog.Error("Failed to import hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		for _, warning := range warnings {
+			slog.Warn("Lost in import: " + warning.String())
+		}
+
+		formatter, err := newFormatter(c.String("to"))
+		if err != nil {
+			slog.Error("Failed to create formatter: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		// an alias such as i3 has the commands and flags of its formatter
+		hotkeys, losses := convert.Convert(hotkeys, importer.System(c.String("from")), formatter.Name())
+		for _, loss := range losses {
+			slog.Warn("Lost in conversion: " + loss.String())
+		}
+
+		lines, err := formatter.Format(hotkeys)
+		if err != nil {
+			slog.Error("Failed to format hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		slog.Info("Converted hotkeys", "hotkeys", len(hotkeys), "import warnings", len(warnings), "losses", len(losses))
+		if _, err := writeLines(c, lines); err != nil {
+			slog.Error("Failed to write output: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/meir/izu/internal/coverage"
+	"github.com/meir/izu/internal/parser"
+	"github.com/urfave/cli/v2"
+)
+
+// coverageCommand reports which systems get a binding for every hotkey
+var coverageCommand = &cli.Command{
+	Name:  "coverage",
+	Usage: "Report for every hotkey which systems get a binding, fall back to the default command or skip it",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Usage:    "Path to the configuration file",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:    "formatter",
+			Aliases: []string{"f"},
+			Usage:   "Formatter to report the coverage of, can be given multiple times (default: all embedded formatters)",
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print the report as JSON",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		content, err := os.ReadFile(c.String("config"))
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		hotkeys, err := parser.Parse(content)
+		if err != nil {
+			slog.Error("Failed to parse hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		formatters, err := newFormatters(c.StringSlice("formatter"))
+		if err != nil {
+			slog.Error("Failed to create formatter: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		report, err := coverage.Coverage(hotkeys, formatters)
+		if err != nil {
+			slog.Error("Failed to create coverage report: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		if !c.Bool("json") {
+			fmt.Print(report.Text())
+			return nil
+		}
+
+		content, err = json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			slog.Error("Failed to encode coverage report: " + err.Error())
+			return cli.Exit("", 1)
+		}
+		fmt.Println(string(content))
+		return nil
+	},
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/pkg/izu"
+)
+
+// luaConfig is the config every lua formatter is created with, it is set from the global flags
+var luaConfig = luaformatter.Config{}
+
+// newFormatter creates the lua formatter, using "auto" detects the formatter from the running session
+func newFormatter(system string) (*luaformatter.Formatter, error) {
+	return newFormatterWithOptions(system, nil)
+}
+
+// newFormatterWithOptions creates the lua formatter with the options, such as the options of a manifest target,
+// the options given on the command line take precedence over these
+func newFormatterWithOptions(system string, options map[string]string) (*luaformatter.Formatter, error) {
+	system, err := resolveSystem(system)
+	if err != nil {
+		return nil, err
+	}
+
+	config := luaConfig
+	config.Options = map[string]string{}
+	for key, value := range options {
+		config.Options[key] = value
+	}
+	for key, value := range luaConfig.Options {
+		config.Options[key] = value
+	}
+	return luaformatter.NewFormatterWithConfig(system, config)
+}
+
+// parseOptions parses the key=value options given on the command line
+func parseOptions(values []string) (map[string]string, error) {
+	options := map[string]string{}
+	for _, option := range values {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("option '%s' should be written as key=value", option)
+		}
+		options[key] = value
+	}
+	return options, nil
+}
+
+// resolveSystem returns the system detected from the running session if the system is "auto"
+func resolveSystem(system string) (string, error) {
+	if system != izu.AutoFormatter {
+		return system, nil
+	}
+
+	detected, err := izu.DetectSystem(os.Getenv)
+	if err != nil {
+		return "", err
+	}
+	slog.Debug("Detected formatter", "formatter", detected)
+	return detected, nil
+}
+
+// newFormatters creates the lua formatter for every system, or for every embedded formatter if no systems are given
+func newFormatters(systems []string) ([]*luaformatter.Formatter, error) {
+	if len(systems) == 0 {
+		names, err := izu.GetFormatterNames("lua")
+		if err != nil {
+			return nil, err
+		}
+		systems = names
+	}
+
+	formatters := []*luaformatter.Formatter{}
+	for _, system := range systems {
+		formatter, err := newFormatter(system)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", system, err)
+		}
+		formatters = append(formatters, formatter)
+	}
+	return formatters, nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/pkg/izu"
+	"github.com/urfave/cli/v2"
+)
+
+// formattersCommand lists the available formatters and shows what they support
+var formattersCommand = &cli.Command{
+	Name:  "formatters",
+	Usage: "List the formatters and show the flags they support",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "list",
+			Usage:     "List the embedded formatters, the formatters of the user and the given formatter files with their version and description",
+			ArgsUsage: "[formatter file...]",
+			Action: func(c *cli.Context) error {
+				names, err := izu.GetFormatterNames("lua")
+				if err != nil {
+					slog.Error("Failed to list formatters: " + err.Error())
+					return cli.Exit("", 1)
+				}
+				// formatters of the user with the name of an embedded formatter replace it, so they are only listed once
+				for _, name := range izu.GetUserFormatterNames("lua") {
+					if !slices.Contains(names, name) {
+						names = append(names, name)
+					}
+				}
+
+				writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(writer, "NAME\tVERSION\tSOURCE\tDESCRIPTION")
+				for _, system := range append(names, c.Args().Slice()...) {
+					formatter, err := newFormatter(system)
+					if err != nil {
+						slog.Error("Failed to create formatter: " + err.Error())
+						return cli.Exit("", 1)
+					}
+
+					metadata := formatter.Metadata()
+					source := "embedded"
+					if path, ok := izu.GetFormatterPath("lua", system); ok {
+						source = path
+					}
+					fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", metadata.Name, metadata.Version, source, metadata.Description)
+				}
+				return writer.Flush()
+			},
+		},
+		{
+			Name:      "show",
+			Usage:     "Show the description, version, aliases and flags of a formatter",
+			ArgsUsage: "<formatter>",
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					slog.Error("Expected the name or path of a single formatter")
+					return cli.Exit("", 1)
+				}
+
+				formatter, err := newFormatter(c.Args().First())
+				if err != nil {
+					slog.Error("Failed to create formatter: " + err.Error())
+					return cli.Exit("", 1)
+				}
+
+				fmt.Print(showMetadata(formatter.Metadata()))
+				return nil
+			},
+		},
+		{
+			Name:      "eject",
+			Usage:     "Copy an embedded formatter into the formatter directory of the user, where it replaces the embedded formatter and can be changed",
+			ArgsUsage: "<formatter>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "dir",
+					Aliases: []string{"d"},
+					Usage:   "Directory to copy the formatter to",
+					Value:   izu.UserFormatterDir(os.Getenv),
+				},
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "Replace the formatter if it was already ejected",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.NArg() != 1 {
+					slog.Error("Expected the name of a single embedded formatter")
+					return cli.Exit("", 1)
+				}
+				name := c.Args().First()
+
+				content, err := izu.GetEmbeddedFormatterFile("lua", name)
+				if err != nil {
+					names, _ := izu.GetFormatterNames("lua")
+					slog.Error(fmt.Sprintf("Unknown embedded formatter '%s', expected one of %s", name, strings.Join(names, ", ")))
+					return cli.Exit("", 1)
+				}
+				if c.String("dir") == "" {
+					slog.Error("Failed to find the formatter directory, neither XDG_CONFIG_HOME or HOME is set")
+					return cli.Exit("", 1)
+				}
+
+				path := filepath.Join(c.String("dir"), name+".lua")
+				if _, err := os.Stat(path); err == nil && !c.Bool("force") {
+					slog.Error("Formatter was already ejected, use --force to replace it", "path", path)
+					return cli.Exit("", 1)
+				}
+
+				if err := os.MkdirAll(c.String("dir"), 0o755); err != nil {
+					slog.Error("Failed to create formatter directory: " + err.Error())
+					return cli.Exit("", 1)
+				}
+				if err := os.WriteFile(path, content, 0o644); err != nil {
+					slog.Error("Failed to write formatter: " + err.Error())
+					return cli.Exit("", 1)
+				}
+
+				slog.Info("Ejected formatter", "formatter", name, "path", path)
+				return nil
+			},
+		},
+	},
+}
+
+// showMetadata formats the metadata of a formatter as text
+func showMetadata(metadata luaformatter.Metadata) string {
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "name:\t%s\n", metadata.Name)
+	if metadata.Version != "" {
+		fmt.Fprintf(writer, "version:\t%s\n", metadata.Version)
+	}
+	if metadata.Description != "" {
+		fmt.Fprintf(writer, "description:\t%s\n", metadata.Description)
+	}
+	if len(metadata.Aliases) > 0 {
+		fmt.Fprintf(writer, "aliases:\t%s\n", strings.Join(metadata.Aliases, ", "))
+	}
+	if metadata.Mode != "" {
+		fmt.Fprintf(writer, "mode flag:\t%s\n", metadata.Mode)
+	}
+	writer.Flush()
+
+	if metadata.Flags == nil {
+		builder.WriteString("\nno flags are declared, any flag is passed to the formatter\n")
+		return builder.String()
+	}
+	if len(metadata.Flags) == 0 {
+		builder.WriteString("\nno flags are accepted\n")
+		return builder.String()
+	}
+
+	builder.WriteString("\nflags:\n")
+	writer = tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+	for _, flag := range metadata.Flags {
+		fmt.Fprintf(writer, "  %s\t%s\t%s\n", flag.Name, flag.Type, flag.Description)
+	}
+	writer.Flush()
+	return builder.String()
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/meir/izu/internal/keyboard"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/internal/query"
+	"github.com/urfave/cli/v2"
+)
+
+// freeCommand lists the keys that are not bound yet for a set of modifiers
+var freeCommand = &cli.Command{
+	Name:  "free",
+	Usage: "List the keys that are still free for a combination of modifiers on a system",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Usage:    "Path to the configuration file",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "formatter",
+			Aliases:  []string{"f"},
+			Usage:    "System to find free keys for, or 'auto' to detect it from the running session",
+			Required: true,
+		},
+		modeFlag,
+		&cli.StringFlag{
+			Name:    "modifiers",
+			Aliases: []string{"m"},
+			Usage:   "Modifiers the free keys are combined with, such as super+shift",
+		},
+		&cli.StringSliceFlag{
+			Name:    "keys",
+			Aliases: []string{"k"},
+			Usage:   "Key sets to search, one of " + strings.Join(query.KeySetNames(), ", "),
+			Value:   cli.NewStringSlice("letters", "digits"),
+		},
+		&cli.BoolFlag{
+			Name:  "keyboard",
+			Usage: "Draw a keyboard with the bound keys marked instead of listing the free keys",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		content, err := os.ReadFile(c.String("config"))
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		hotkeys, err := parser.Parse(content)
+		if err != nil {
+			slog.Error("Failed to parse hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		formatter, err := newFormatter(c.String("formatter"))
+		if err != nil {
+			slog.Error("Failed to create formatter: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		bindings, err := expandBindings(formatter, hotkeys, c.String("mode"))
+		if err != nil {
+			slog.Error("Failed to format hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		modifiers := query.ParseCombo(c.String("modifiers"))
+		bound := query.NewBound(bindings)
+
+		if c.Bool("keyboard") {
+			fmt.Print(keyboard.ANSI.ASCII(func(name string) bool {
+				return bound.Contains(modifiers, name)
+			}))
+			return nil
+		}
+
+		keys := []string{}
+		for _, set := range c.StringSlice("keys") {
+			setKeys, err := query.KeySet(set)
+			if err != nil {
+				slog.Error("Failed to get key set: " + err.Error())
+				return cli.Exit("", 1)
+			}
+			keys = append(keys, setKeys...)
+		}
+
+		for _, key := range bound.Free(modifiers, keys) {
+			fmt.Println(strings.Join(append(append([]string{}, modifiers...), key), "+"))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/meir/izu/internal/parser"
+	"github.com/urfave/cli/v2"
+)
+
+// generateFlags are the flags to generate a config, these are also accepted without the generate command
+var generateFlags = append([]cli.Flag{
+	&cli.StringFlag{
+		Name:    "config",
+		Aliases: []string{"c"},
+		Usage:   "Path to the configuration file",
+	},
+	&cli.StringFlag{
+		Name:    "formatter",
+		Aliases: []string{"f"},
+		Usage:   "Path to the formatter lua file, or 'auto' to detect it from the running session",
+	},
+	&cli.StringFlag{
+		Name:    "string",
+		Aliases: []string{"s"},
+		Usage:   "String to parse",
+	},
+}, outputFlags...)
+
+// generateCommand generates the config for a single hotkey daemon
+var generateCommand = &cli.Command{
+	Name:   "generate",
+	Usage:  "Generate the config for a hotkey daemon, this is the default command",
+	Flags:  generateFlags,
+	Action: generate,
+}
+
+// generate reads the config or string, formats it and writes the output
+func generate(c *cli.Context) error {
+	input := []byte(c.String("string"))
+	if c.String("config") != "" {
+		content, err := os.ReadFile(c.String("config"))
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+		input = content
+	}
+
+	hotkeys, err := parser.Parse([]byte(input))
+	if err != nil {
+		slog.Error("Failed to parse hotkeys: " + err.Error())
+		return cli.Exit("", 1)
+	}
+
+	formatter, err := newFormatter(c.String("formatter"))
+	if err != nil {
+		slog.Error("Failed to create formatter: " + err.Error())
+		return cli.Exit("", 1)
+	}
+
+	lines, err := formatter.Format(hotkeys)
+	if err != nil {
+		slog.Error("Failed to format hotkeys: " + err.Error())
+		return cli.Exit("", 1)
+	}
+
+	if _, err := writeLines(c, lines); err != nil {
+		slog.Error("Failed to write output: " + err.Error())
+		return cli.Exit("", 1)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/meir/izu/internal/importer"
+	"github.com/urfave/cli/v2"
+)
+
+// importCommand converts the config of an existing hotkey daemon into an izu config
+var importCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Convert the config of an existing hotkey daemon into an izu config",
+	ArgsUsage: "<config file>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "from",
+			Usage:    "System of the config to import (sway, i3, hyprland, niri)",
+			Required: true,
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			slog.Error("Expected exactly one config file to import")
+			return cli.Exit("", 1)
+		}
+
+		content, err := os.ReadFile(c.Args().First())
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		imp, err := importer.Get(c.String("from"))
+		if err != nil {
+			slog.Error("Failed to create importer: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		hotkeys, warnings, err := imp.Import(content)
+		if err != nil {
+			slog.Error("Failed to import hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		for _, warning := range warnings {
+			slog.Warn(warning.String())
+		}
+
+		for _, hotkey := range hotkeys {
+			fmt.Println(hotkey.String())
+		}
+
+		return nil
+	},
+}
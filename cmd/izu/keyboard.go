@@ -0,0 +1,112 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/meir/izu/internal/keyboard"
+	"github.com/meir/izu/internal/output"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/internal/query"
+	"github.com/urfave/cli/v2"
+)
+
+// keyboardCommand draws a keyboard heatmap of the bound keys for every modifier layer
+var keyboardCommand = &cli.Command{
+	Name:  "keyboard",
+	Usage: "Write an svg keyboard heatmap of the bound keys for every combination of modifiers",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Usage:    "Path to the configuration file",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "formatter",
+			Aliases:  []string{"f"},
+			Usage:    "System to draw the bound keys of, or 'auto' to detect it from the running session",
+			Required: true,
+		},
+		modeFlag,
+		&cli.StringFlag{
+			Name:    "layout",
+			Aliases: []string{"l"},
+			Usage:   "Physical keyboard layout, one of " + strings.Join(keyboard.Names(), ", "),
+			Value:   "ansi",
+		},
+		&cli.StringSliceFlag{
+			Name:    "modifiers",
+			Aliases: []string{"m"},
+			Usage:   "Only draw the layer of these modifiers, such as super+shift, can be given multiple times (default: every layer)",
+		},
+		&cli.StringFlag{
+			Name:    "dir",
+			Aliases: []string{"d"},
+			Usage:   "Directory to write the svg files to, named <system>-<modifiers>.svg",
+			Value:   ".",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		content, err := os.ReadFile(c.String("config"))
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		hotkeys, err := parser.Parse(content)
+		if err != nil {
+			slog.Error("Failed to parse hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		formatter, err := newFormatter(c.String("formatter"))
+		if err != nil {
+			slog.Error("Failed to create formatter: " + err.Error())
+			return cli.Exit("", 1)
+		}
+		system := formatter.System()
+
+		bindings, err := expandBindings(formatter, hotkeys, c.String("mode"))
+		if err != nil {
+			slog.Error("Failed to format hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		layout, err := keyboard.Get(c.String("layout"))
+		if err != nil {
+			slog.Error("Failed to get keyboard layout: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		// the requested modifiers are compared by their layer name, so super+shift also selects shift+super
+		selected := []string{}
+		for _, modifiers := range c.StringSlice("modifiers") {
+			selected = append(selected, query.Layer{Modifiers: query.Modifiers(query.ParseCombo(modifiers))}.Name())
+		}
+
+		for _, layer := range query.Layers(bindings) {
+			if len(selected) > 0 && !slices.Contains(selected, layer.Name()) {
+				continue
+			}
+
+			name := system + "-" + strings.ReplaceAll(layer.Name(), "+", "-")
+			path := filepath.Join(c.String("dir"), name+".svg")
+			changed, err := output.Write(path, layout.SVG(system+" "+layer.Name(), layer), false)
+			if err != nil {
+				slog.Error("Failed to write keyboard: " + err.Error())
+				return cli.Exit("", 1)
+			}
+
+			if changed {
+				slog.Info("Written", "output", path)
+			} else {
+				slog.Info("Unchanged", "output", path)
+			}
+		}
+		return nil
+	},
+}
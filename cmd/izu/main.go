@@ -1,13 +1,11 @@
 package main
 
 import (
-	"fmt"
 	"log/slog"
 	"math"
 	"os"
 
 	"github.com/meir/izu/internal/luaformatter"
-	"github.com/meir/izu/internal/parser"
 	"github.com/meir/izu/pkg/izu"
 	"github.com/phsym/console-slog"
 	"github.com/urfave/cli/v2"
@@ -17,17 +15,7 @@ func main() {
 	(&cli.App{
 		Name:  "izu",
 		Usage: "A unified hotkey config based on sxhkd.",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:    "config",
-				Aliases: []string{"c"},
-				Usage:   "Path to the configuration file",
-			},
-			&cli.StringFlag{
-				Name:    "formatter",
-				Aliases: []string{"f"},
-				Usage:   "Path to the formatter lua file",
-			},
+		Flags: append([]cli.Flag{
 			&cli.BoolFlag{
 				Name:    "version",
 				Aliases: []string{"v"},
@@ -43,13 +31,41 @@ func main() {
 				Aliases: []string{"S"},
 				Usage:   "Silent output, does not output any logs or errors unless when panicking",
 			},
-			&cli.StringFlag{
-				Name:    "string",
-				Aliases: []string{"s"},
-				Usage:   "String to parse",
+			&cli.BoolFlag{
+				Name:  "unsafe-lua",
+				Usage: "Run lua formatters with every library, allowing them to run commands and access files",
+			},
+			&cli.DurationFlag{
+				Name:  "lua-timeout",
+				Usage: "Time a single call into a lua formatter may take before it is stopped",
+				Value: luaformatter.DefaultTimeout,
 			},
+			&cli.BoolFlag{
+				Name:  "strict-flags",
+				Usage: "Stop formatting when a hotkey has a flag its formatter does not accept, instead of warning about it",
+			},
+			&cli.StringSliceFlag{
+				Name:    "option",
+				Aliases: []string{"o"},
+				Usage:   "Option for the lua formatter written as key=value, available to the formatter as izu.options, can be given multiple times",
+			},
+		}, generateFlags...),
+		Commands: []*cli.Command{
+			generateCommand,
+			checkCommand,
+			coverageCommand,
+			queryCommand,
+			freeCommand,
+			keyboardCommand,
+			menuCommand,
+			importCommand,
+			convertCommand,
+			watchCommand,
+			buildCommand,
+			formattersCommand,
+			testFormatterCommand,
 		},
-		Action: func(c *cli.Context) error {
+		Before: func(c *cli.Context) error {
 			level := slog.LevelInfo
 			if c.Bool("verbose") {
 				level = slog.LevelDebug
@@ -60,44 +76,28 @@ func main() {
 				Level: level,
 			})))
 
-			if c.Bool("version") {
-				slog.Info("Izu Version " + izu.GetVersion())
-				return nil
-			}
-
-			input := []byte(c.String("string"))
-			if c.String("config") != "" {
-				content, err := os.ReadFile(c.String("config"))
-				if err != nil {
-					slog.Error("Failed to read config file: " + err.Error())
-					return cli.Exit("", 1)
-				}
-				input = content
-			}
-
-			hotkeys, err := parser.Parse([]byte(input))
+			options, err := parseOptions(c.StringSlice("option"))
 			if err != nil {
-				slog.Error("Failed to parse hotkeys: " + err.Error())
+				slog.Error("Failed to parse formatter options: " + err.Error())
 				return cli.Exit("", 1)
 			}
 
-			formatter, err := luaformatter.NewFormatter(c.String("formatter"))
-			if err != nil {
-				slog.Error("Failed to create formatter: " + err.Error())
-				return cli.Exit("", 1)
+			luaConfig = luaformatter.Config{
+				Unsafe:  c.Bool("unsafe-lua"),
+				Timeout: c.Duration("lua-timeout"),
+				Options: options,
+				Strict:  c.Bool("strict-flags"),
 			}
-
-			lines, err := formatter.Format(hotkeys)
-			if err != nil {
-				slog.Error("Failed to format hotkeys: " + err.Error())
-				return cli.Exit("", 1)
-			}
-
-			for _, line := range lines {
-				fmt.Println(line)
+			return nil
+		},
+		Action: func(c *cli.Context) error {
+			if c.Bool("version") {
+				slog.Info("Izu Version " + izu.GetVersion())
+				return nil
 			}
 
-			return nil
+			// izu without a command generates a config, like it did before there were commands
+			return generate(c)
 		},
 	}).Run(os.Args)
 }
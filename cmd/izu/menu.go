@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+
+	"github.com/meir/izu/internal/menu"
+	"github.com/meir/izu/internal/parser"
+	"github.com/urfave/cli/v2"
+)
+
+// menuCommand prints the hotkeys for a launcher and runs the command of the selected hotkey
+var menuCommand = &cli.Command{
+	Name:  "menu",
+	Usage: "Print a line for every hotkey for dmenu, rofi or fzf, or run the hotkey of the line selected on stdin",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Usage:    "Path to the configuration file",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "formatter",
+			Aliases:  []string{"f"},
+			Usage:    "Path to the formatter lua file, or 'auto' to detect it from the running session",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:    "run",
+			Aliases: []string{"r"},
+			Usage:   "Read the selected line from stdin and run its command",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		content, err := os.ReadFile(c.String("config"))
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		hotkeys, err := parser.Parse(content)
+		if err != nil {
+			slog.Error("Failed to parse hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		formatter, err := newFormatter(c.String("formatter"))
+		if err != nil {
+			slog.Error("Failed to create formatter: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		// the bindings are paired with their commands exactly like the generated config
+		bindings, _, err := formatter.Expand(hotkeys)
+		if err != nil {
+			slog.Error("Failed to format hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		if !c.Bool("run") {
+			for _, line := range menu.Lines(bindings) {
+				fmt.Println(line)
+			}
+			return nil
+		}
+
+		selected, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && selected == "" {
+			// nothing was selected in the launcher
+			slog.Debug("No line selected")
+			return nil
+		}
+
+		binding, ok := menu.Find(bindings, selected)
+		if !ok {
+			slog.Error("Selected line does not match any hotkey", "line", selected)
+			return cli.Exit("", 1)
+		}
+
+		args, err := formatter.Dispatch(binding)
+		if err != nil {
+			slog.Error("Failed to get the command to run: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		// the command is started without waiting for it, so the launcher can close while the command keeps running
+		slog.Info("Running", "command", args)
+		process := exec.Command(args[0], args[1:]...)
+		if err := process.Start(); err != nil {
+			slog.Error("Failed to run command: " + err.Error())
+			return cli.Exit("", 1)
+		}
+		return process.Process.Release()
+	},
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/meir/izu/internal/output"
+	"github.com/urfave/cli/v2"
+)
+
+// outputFlags are the flags for commands that write a generated config
+var outputFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "output",
+		Usage: "Path to write the output to instead of stdout, the file is only replaced when the content changes",
+	},
+	&cli.StringFlag{
+		Name:  "inject",
+		Usage: "Path of an existing config to write the output into, between the '" + output.BeginMarker + "' and '" + output.EndMarker + "' comments",
+	},
+	&cli.BoolFlag{
+		Name:  "backup",
+		Usage: "Keep the previous generation of the output file with a .bak suffix",
+	},
+}
+
+// writeLines prints the lines or writes them to the output given by the output flags
+// it returns false if the file already contained the output, printing always counts as a change
+func writeLines(c *cli.Context, lines []string) (bool, error) {
+	return writeOutput(lines, c.String("output"), c.String("inject"), c.Bool("backup"))
+}
+
+// writeOutput prints the lines or writes them to the output file if one is given
+// if a file to inject into is given, only the managed block within that file is replaced
+func writeOutput(lines []string, path, inject string, backup bool) (bool, error) {
+	content := []byte(strings.Join(lines, "\n") + "\n")
+
+	switch {
+	case path != "" && inject != "":
+		return false, fmt.Errorf("cannot use --output and --inject at the same time")
+	case inject != "":
+		path = inject
+		target, err := os.ReadFile(path)
+		if err != nil {
+			return false, fmt.Errorf("failed to read file to inject into: %w", err)
+		}
+
+		content, err = output.Inject(target, content)
+		if err != nil {
+			return false, fmt.Errorf("refusing to inject into %s: %w", path, err)
+		}
+	case path == "":
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+		return true, nil
+	}
+
+	changed, err := output.Write(path, content, backup)
+	if err != nil {
+		return false, err
+	}
+
+	if changed {
+		slog.Info("Written", "output", path)
+	} else {
+		slog.Info("Unchanged", "output", path)
+	}
+	return changed, nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/internal/query"
+	"github.com/meir/izu/pkg/izu"
+	"github.com/urfave/cli/v2"
+)
+
+// modeFlag selects the mode of the hotkey daemon that query, free and keyboard use the bindings of
+var modeFlag = &cli.StringFlag{
+	Name:  "mode",
+	Usage: "Use the bindings in this mode of the hotkey daemon, such as a sway mode or hyprland submap (default: the bindings outside of a mode)",
+}
+
+// expandBindings formats the hotkeys in the same way as generate and returns every key combination in the mode
+func expandBindings(formatter *luaformatter.Formatter, hotkeys []*izu.Hotkey, mode string) ([]query.Binding, error) {
+	if mode != "" && formatter.Metadata().Mode == "" {
+		return nil, fmt.Errorf("formatter %s does not have modes", formatter.System())
+	}
+
+	bindings, _, err := formatter.Expand(hotkeys)
+	if err != nil {
+		return nil, err
+	}
+	return query.Bindings(bindings, mode), nil
+}
+
+// queryCommand looks up what a key combination does on a system
+var queryCommand = &cli.Command{
+	Name:      "query",
+	Usage:     "Print the command a key combination fires on a system and where it is defined",
+	ArgsUsage: "<key combination>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Usage:    "Path to the configuration file",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "formatter",
+			Aliases:  []string{"f"},
+			Usage:    "System to look up the command for, or 'auto' to detect it from the running session",
+			Required: true,
+		},
+		modeFlag,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() == 0 {
+			slog.Error("Expected a key combination to look up, such as super+shift+s")
+			return cli.Exit("", 1)
+		}
+		combo := strings.Join(c.Args().Slice(), " ")
+
+		path := c.String("config")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			slog.Error("Failed to read config file: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		hotkeys, err := parser.Parse(content)
+		if err != nil {
+			slog.Error("Failed to parse hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		formatter, err := newFormatter(c.String("formatter"))
+		if err != nil {
+			slog.Error("Failed to create formatter: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		expanded, err := expandBindings(formatter, hotkeys, c.String("mode"))
+		if err != nil {
+			slog.Error("Failed to format hotkeys: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		bindings := query.Query(expanded, combo)
+		if len(bindings) == 0 {
+			slog.Warn("Key combination is not bound", "combination", combo, "system", formatter.System(), "mode", c.String("mode"))
+			return cli.Exit("", 1)
+		}
+
+		for _, binding := range bindings {
+			details := []string{}
+			if binding.Default {
+				details = append(details, "default command")
+			}
+			if len(binding.Flags) > 0 {
+				details = append(details, "flags: "+strings.Join(izu.FlagStrings(binding.Flags), " "))
+			}
+
+			line := fmt.Sprintf("%s:%d: %s", path, binding.Hotkey.Line, binding.Command)
+			if len(details) > 0 {
+				line += " (" + strings.Join(details, ", ") + ")"
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/meir/izu/internal/golden"
+	"github.com/urfave/cli/v2"
+)
+
+// testFormatterCommand compares the output of a formatter to the expected outputs in a directory
+var testFormatterCommand = &cli.Command{
+	Name:      "test-formatter",
+	Usage:     "Format every .izu config in a directory and compare the output to the .golden file next to it",
+	ArgsUsage: "<formatter> <dir>",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "update",
+			Aliases: []string{"u"},
+			Usage:   "Replace the .golden files with the output of the formatter",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 2 {
+			slog.Error("Expected a formatter and a directory with test cases")
+			return cli.Exit("", 1)
+		}
+
+		formatter, err := newFormatter(c.Args().Get(0))
+		if err != nil {
+			slog.Error("Failed to create formatter: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		results, err := golden.Run(formatter, c.Args().Get(1), c.Bool("update"))
+		if err != nil {
+			slog.Error("Failed to run test cases: " + err.Error())
+			return cli.Exit("", 1)
+		}
+
+		failed := 0
+		for _, result := range results {
+			switch {
+			case result.Err != nil:
+				fmt.Printf("FAIL %s: %s\n", result.Case.Name, result.Err.Error())
+			case result.Updated:
+				fmt.Printf("UPDATED %s\n", result.Case.Name)
+			case result.Diff != "":
+				fmt.Printf("FAIL %s\n%s", result.Case.Name, result.Diff)
+			default:
+				fmt.Printf("ok %s\n", result.Case.Name)
+			}
+			if !result.Passed() {
+				failed++
+			}
+		}
+
+		slog.Info("Tested formatter", "formatter", formatter.System(), "cases", len(results), "failed", failed)
+		if failed > 0 {
+			return cli.Exit("", 1)
+		}
+		return nil
+	},
+}
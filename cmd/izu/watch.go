@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+	"github.com/urfave/cli/v2"
+)
+
+// watchCommand regenerates the output whenever the config or formatter changes and reloads the hotkey daemon
+var watchCommand = &cli.Command{
+	Name:  "watch",
+	Usage: "Regenerate the output when the config or formatter changes and reload the hotkey daemon",
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:     "config",
+			Aliases:  []string{"c"},
+			Usage:    "Path to the configuration file",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "formatter",
+			Aliases:  []string{"f"},
+			Usage:    "Path to the formatter lua file, or 'auto' to detect it from the running session",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "reload",
+			Usage: "Command to run after the output changed, overrides the reload command of the formatter",
+		},
+		&cli.StringSliceFlag{
+			Name:  "watch",
+			Usage: "Additional files to watch for changes",
+		},
+		&cli.DurationFlag{
+			Name:  "interval",
+			Usage: "Interval to check the files for changes",
+			Value: 500 * time.Millisecond,
+		},
+	}, outputFlags...),
+	Action: func(c *cli.Context) error {
+		if c.String("output") == "" && c.String("inject") == "" {
+			slog.Error("Watching requires either --output or --inject")
+			return cli.Exit("", 1)
+		}
+
+		files := append([]string{c.String("config")}, c.StringSlice("watch")...)
+		if path, ok := izu.GetFormatterPath("lua", c.String("formatter")); ok {
+			files = append(files, path)
+		}
+
+		ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		slog.Info("Watching for changes", "files", files)
+		states := map[string]fileState{}
+		ticker := time.NewTicker(c.Duration("interval"))
+		defer ticker.Stop()
+		for {
+			changed := false
+			for _, file := range files {
+				state := statFile(file)
+				if state != states[file] {
+					states[file] = state
+					changed = true
+				}
+			}
+
+			if changed {
+				regenerate(c)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// fileState is used to check if a file changed between two checks
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// statFile returns the current state of the file
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{true, info.Size(), info.ModTime()}
+}
+
+// regenerate parses and formats the config, writes the output and reloads the hotkey daemon if the output changed
+// any error is logged and leaves the last output in place
+func regenerate(c *cli.Context) {
+	content, err := os.ReadFile(c.String("config"))
+	if err != nil {
+		slog.Error("Failed to read config file, keeping the last output: " + err.Error())
+		return
+	}
+
+	hotkeys, err := parser.Parse(content)
+	if err != nil {
+		slog.Error("Failed to parse hotkeys, keeping the last output: " + err.Error())
+		return
+	}
+
+	formatter, err := newFormatter(c.String("formatter"))
+	if err != nil {
+		slog.Error("Failed to create formatter, keeping the last output: " + err.Error())
+		return
+	}
+
+	lines, err := formatter.Format(hotkeys)
+	if err != nil {
+		slog.Error("Failed to format hotkeys, keeping the last output: " + err.Error())
+		return
+	}
+
+	changed, err := writeLines(c, lines)
+	if err != nil {
+		slog.Error("Failed to write output: " + err.Error())
+		return
+	}
+	if !changed {
+		return
+	}
+
+	command := c.String("reload")
+	if command == "" {
+		command = formatter.Reload()
+	}
+	if err := reload(c.Context, command); err != nil {
+		slog.Error("Failed to reload: " + err.Error())
+	}
+}
+
+// reload runs the reload command using the shell
+func reload(ctx context.Context, command string) error {
+	if command == "" {
+		return nil
+	}
+
+	slog.Info("Reloading", "command", command)
+	output, err := exec.CommandContext(ctx, "sh", "-c", command).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, output)
+	}
+	return nil
+}
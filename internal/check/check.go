@@ -0,0 +1,157 @@
+package check
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/internal/query"
+	"github.com/meir/izu/pkg/izu"
+)
+
+// Severity is how bad an issue found in the config is
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (severity Severity) String() string {
+	if severity == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Issue is a single problem found in the config
+type Issue struct {
+	// Line is the line of the hotkey in the config, 0 when the issue is not about a single hotkey
+	Line     int
+	System   string
+	Severity Severity
+	Message  string
+}
+
+func (issue Issue) String() string {
+	message := fmt.Sprintf("%s: %s", issue.Severity.String(), issue.Message)
+	if issue.System != "" {
+		message += fmt.Sprintf(" (%s)", issue.System)
+	}
+	if issue.Line > 0 {
+		message = fmt.Sprintf("line %d: %s", issue.Line, message)
+	}
+	return message
+}
+
+// HasErrors returns true if any of the issues is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Check parses the config and formats it with every given formatter without writing anything
+// every problem that is found is returned as an issue, the config is valid if none of them are errors
+func Check(config []byte, formatters []*luaformatter.Formatter) []Issue {
+	hotkeys, err := parser.Parse(config)
+	if err != nil {
+		return []Issue{{
+			Severity: SeverityError,
+			Message:  "failed to parse config: " + strings.TrimSpace(err.Error()),
+		}}
+	}
+
+	issues := systems(hotkeys, formatters)
+	for _, formatter := range formatters {
+		issues = append(issues, format(hotkeys, formatter)...)
+	}
+
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		return a.Line - b.Line
+	})
+	return issues
+}
+
+// systems reports the systems used in flags and commands that have no formatter, these are most likely typos
+func systems(hotkeys []*izu.Hotkey, formatters []*luaformatter.Formatter) []Issue {
+	known := []string{"default"}
+	if names, err := izu.GetFormatterNames("lua"); err == nil {
+		known = append(known, names...)
+	}
+	known = append(known, izu.GetUserFormatterNames("lua")...)
+	for _, formatter := range formatters {
+		known = append(known, formatter.System())
+		known = append(known, formatter.Metadata().Aliases...)
+	}
+
+	issues := []Issue{}
+	for _, hotkey := range hotkeys {
+		for system := range hotkey.Flags {
+			if !slices.Contains(known, system) {
+				issues = append(issues, Issue{hotkey.Line, system, SeverityWarning, fmt.Sprintf("flags are given for the unknown system '%s'", system)})
+			}
+		}
+		for system := range hotkey.Command {
+			if !slices.Contains(known, system) {
+				issues = append(issues, Issue{hotkey.Line, system, SeverityWarning, fmt.Sprintf("a command is given for the unknown system '%s'", system)})
+			}
+		}
+	}
+
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		return strings.Compare(a.Message, b.Message)
+	})
+	return issues
+}
+
+// format formats every hotkey on its own so errors can be traced back to the hotkey
+// and reports hotkeys without a command and bindings that are bound more than once
+func format(hotkeys []*izu.Hotkey, formatter *luaformatter.Formatter) []Issue {
+	system := formatter.System()
+	issues := []Issue{}
+	bound := map[string]*izu.Hotkey{}
+	for _, hotkey := range hotkeys {
+		if errs := formatter.ValidateFlags([]*izu.Hotkey{hotkey}); len(errs) > 0 {
+			for _, err := range errs {
+				issues = append(issues, Issue{hotkey.Line, system, SeverityError, err.Message})
+			}
+			continue
+		}
+
+		bindings, skipped, err := formatter.Expand([]*izu.Hotkey{hotkey})
+		if err != nil {
+			issues = append(issues, Issue{hotkey.Line, system, SeverityError, strings.TrimSpace(err.Error())})
+			continue
+		}
+		if len(skipped) > 0 {
+			issues = append(issues, Issue{hotkey.Line, system, SeverityWarning, fmt.Sprintf("hotkey '%s' has no command and is skipped", hotkey.Binding.String())})
+		}
+
+		// every key combination is compared, as formatters such as sxhkd keep several combinations in one binding
+		// bindings only conflict when they are bound with the same flags, a binding in another mode is fine
+		for _, binding := range bindings {
+			flags := izu.FlagStrings(binding.Flags)
+			slices.Sort(flags)
+			for _, combo := range binding.Combos {
+				name := strings.Join(combo.Keys, "+")
+				key := strings.Join(query.Normalise(combo.Keys), "+") + "|" + strings.Join(flags, " ")
+				if other, ok := bound[key]; ok {
+					message := fmt.Sprintf("'%s' is already bound on line %d", name, other.Line)
+					if other == hotkey {
+						message = fmt.Sprintf("'%s' is bound more than once by the same hotkey", name)
+					}
+					issues = append(issues, Issue{hotkey.Line, system, SeverityError, message})
+					continue
+				}
+				bound[key] = hotkey
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,117 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/pkg/izu"
+)
+
+func TestCheck(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases := []struct {
+		input  string
+		issues []Issue
+	}{
+		{
+			input: `super + a
+  foot`,
+			issues: []Issue{},
+		},
+		{
+			input: `super + a
+  foot
+
+super + b | hyprland[l]
+  hyprland | exec foot`,
+			issues: []Issue{
+				{4, "sway", SeverityWarning, "hotkey 'super + b' has no command and is skipped"},
+			},
+		},
+		{
+			input: `super + a
+  foot
+
+super + {a,b}
+  {foot,firefox}`,
+			issues: []Issue{
+				{4, "sway", SeverityError, "'super+a' is already bound on line 1"},
+			},
+		},
+		{
+			input: `super + a
+  foot
+
+super + a | sway[mode=resize]
+  sway | resize shrink width 10px`,
+			issues: []Issue{},
+		},
+		{
+			input: `super + a | swya[release]
+  foot`,
+			issues: []Issue{
+				{1, "swya", SeverityWarning, "flags are given for the unknown system 'swya'"},
+			},
+		},
+		{
+			input: `super + a | sway[relase]
+  foot
+
+super + b | sway[mode]
+  foot`,
+			issues: []Issue{
+				{1, "sway", SeverityError, "unknown flag 'relase', expected one of release, locked, to-code, whole-window, border, exclude-titlebar, inhibited, no-warn, no-repeat, bindcode, input-device, mode"},
+				{4, "sway", SeverityError, "flag 'mode' expects a string value, written as mode=<value>"},
+			},
+		},
+		{
+			input: `super + a | sway[release] sway[locked]
+  foot`,
+			issues: []Issue{
+				{0, "", SeverityError, "failed to parse config: flag 'sway' already exists"},
+			},
+		},
+	}
+
+	formatter, err := luaformatter.NewFormatter("sway")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for case_index, c := range cases {
+		issues := Check([]byte(c.input), []*luaformatter.Formatter{formatter})
+		if diff := deep.Equal(issues, c.issues); diff != nil {
+			t.Errorf("#%d: %v", case_index, diff)
+		}
+		if HasErrors(issues) != HasErrors(c.issues) {
+			t.Errorf("#%d: HasErrors returned %v", case_index, HasErrors(issues))
+		}
+	}
+}
+
+func TestCheckCombos(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	input := `super + {a,b}
+  {foot,firefox}
+
+super + a
+  foot`
+
+	// sxhkd keeps both combinations of the first hotkey in a single binding
+	formatter, err := luaformatter.NewFormatter("sxhkd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues := Check([]byte(input), []*luaformatter.Formatter{formatter})
+	if diff := deep.Equal(issues, []Issue{{4, "sxhkd", SeverityError, "'super+a' is already bound on line 1"}}); diff != nil {
+		t.Error(diff)
+	}
+}
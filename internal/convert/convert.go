@@ -0,0 +1,197 @@
+package convert
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+)
+
+// Loss describes a part of a hotkey that could not be carried over to the target system
+type Loss struct {
+	Hotkey  *izu.Hotkey
+	Message string
+}
+
+// String returns the loss with the binding of the hotkey it belongs to
+func (l Loss) String() string {
+	return fmt.Sprintf("%s: %s", l.Hotkey.Binding.String(), l.Message)
+}
+
+// commands is a list of commands that do the same thing on each system
+// the first command of a system is used when converting to it, %s is the argument of the command
+var commands = []map[string][]string{
+	{"sway": {"kill"}, "hyprland": {"killactive,"}, "niri": {"close-window;"}},
+	{"sway": {"fullscreen", "fullscreen toggle"}, "hyprland": {"fullscreen,"}, "niri": {"fullscreen-window;"}},
+	{"sway": {"floating toggle"}, "hyprland": {"togglefloating,"}, "niri": {"toggle-window-floating;"}},
+	{"sway": {"exit"}, "hyprland": {"exit,"}, "niri": {"quit;"}},
+	{"sway": {"workspace number %s", "workspace %s"}, "hyprland": {"workspace, %s"}, "niri": {"focus-workspace %s;"}},
+	{"sway": {"move container to workspace number %s", "move container to workspace %s", "move window to workspace number %s"}, "hyprland": {"movetoworkspace, %s"}, "niri": {"move-column-to-workspace %s;"}},
+	{"sway": {"focus left"}, "hyprland": {"movefocus, l"}, "niri": {"focus-column-left;"}},
+	{"sway": {"focus right"}, "hyprland": {"movefocus, r"}, "niri": {"focus-column-right;"}},
+	{"sway": {"focus up"}, "hyprland": {"movefocus, u"}, "niri": {"focus-window-up;"}},
+	{"sway": {"focus down"}, "hyprland": {"movefocus, d"}, "niri": {"focus-window-down;"}},
+	{"sway": {"move left"}, "hyprland": {"movewindow, l"}, "niri": {"move-column-left;"}},
+	{"sway": {"move right"}, "hyprland": {"movewindow, r"}, "niri": {"move-column-right;"}},
+	{"sway": {"move up"}, "hyprland": {"movewindow, u"}, "niri": {"move-window-up;"}},
+	{"sway": {"move down"}, "hyprland": {"movewindow, d"}, "niri": {"move-window-down;"}},
+}
+
+// flags is a list of flags that have the same meaning on each system
+var flags = []map[string]string{
+	{"sway": "locked", "hyprland": "l", "niri": "allow-when-locked"},
+	{"sway": "release", "hyprland": "r"},
+	{"sway": "no-repeat", "niri": "no-repeat"},
+	{"sway": "bindcode"},
+}
+
+// valueFlags is a list of key/value flags that have the same meaning on each system, such as sway modes and hyprland submaps
+var valueFlags = []map[string]string{
+	{"sway": "mode", "hyprland": "submap"},
+}
+
+// unsupportedKeys checks if a key cannot be bound on the system
+var unsupportedKeys = map[string]func(string) bool{
+	"sway": func(key string) bool {
+		return strings.HasPrefix(key, "mouse_")
+	},
+	"sxhkd": func(key string) bool {
+		return strings.HasPrefix(key, "mouse_")
+	},
+	"hyprland": func(key string) bool {
+		key = strings.ToLower(key)
+		return strings.HasPrefix(key, "wheelscroll") || strings.HasPrefix(key, "touchpadscroll")
+	},
+	"niri": func(key string) bool {
+		return strings.HasPrefix(key, "mouse_x")
+	},
+}
+
+// Convert converts the hotkeys written for one system so they can be formatted for another system
+// system specific commands and flags are translated where an equivalent exists, everything else is reported as a loss
+func Convert(hotkeys []*izu.Hotkey, from, to string) ([]*izu.Hotkey, []Loss) {
+	output := []*izu.Hotkey{}
+	losses := []Loss{}
+	for _, hotkey := range hotkeys {
+		converted := &izu.Hotkey{
+			Binding:     hotkey.Binding,
+			Flags:       map[string][]izu.Flag{},
+			Command:     map[string]izu.Part{},
+			Line:        hotkey.Line,
+			Description: hotkey.Description,
+		}
+		for system, values := range hotkey.Flags {
+			converted.Flags[system] = append([]izu.Flag{}, values...)
+		}
+		for system, command := range hotkey.Command {
+			converted.Command[system] = command
+		}
+
+		for _, flag := range hotkey.Flags[from] {
+			if from == to {
+				break
+			}
+			if equivalent, ok := convertFlag(flag, from, to); ok {
+				converted.Flags[to] = append(converted.Flags[to], equivalent)
+			} else {
+				losses = append(losses, Loss{hotkey, fmt.Sprintf("flag %s[%s] has no equivalent on %s", from, flag.String(), to)})
+			}
+		}
+
+		if _, ok := converted.Command[to]; !ok {
+			if command, ok := hotkey.Command[from]; ok {
+				if equivalent, ok := convertCommand(command.String(), from, to); ok {
+					converted.Command[to] = parser.NewPartBinding("", parser.NewPartString(equivalent))
+				} else if _, ok := hotkey.Command["default"]; !ok {
+					losses = append(losses, Loss{hotkey, fmt.Sprintf("command '%s' has no equivalent on %s, the hotkey is skipped", command.String(), to)})
+				}
+			} else if _, ok := hotkey.Command["default"]; !ok {
+				losses = append(losses, Loss{hotkey, fmt.Sprintf("there is no command for %s, the hotkey is skipped", to)})
+			}
+		}
+
+		if unsupported, ok := unsupportedKeys[to]; ok {
+			for _, key := range keys(hotkey.Binding) {
+				if unsupported(key) || strings.Contains(key, ":") {
+					losses = append(losses, Loss{hotkey, fmt.Sprintf("key '%s' cannot be bound on %s", key, to)})
+				}
+			}
+		}
+
+		output = append(output, converted)
+	}
+	return output, losses
+}
+
+// convertFlag returns the flag of the target system with the same meaning
+func convertFlag(flag izu.Flag, from, to string) (izu.Flag, bool) {
+	for _, equivalent := range flags {
+		if equivalent[from] == flag.Name && !flag.HasValue() && equivalent[to] != "" {
+			return izu.Flag{Name: equivalent[to]}, true
+		}
+	}
+	for _, equivalent := range valueFlags {
+		if equivalent[from] == "" || equivalent[to] == "" {
+			continue
+		}
+		if flag.Name == equivalent[from] && flag.HasValue() {
+			return izu.Flag{Name: equivalent[to], Value: flag.Value}, true
+		}
+		// values used to be written as part of the name, such as mode-resize
+		if value, ok := strings.CutPrefix(flag.Name, equivalent[from]+"-"); ok && !flag.HasValue() {
+			return izu.Flag{Name: equivalent[to], Value: value}, true
+		}
+	}
+	return izu.Flag{}, false
+}
+
+// convertCommand returns the command of the target system with the same meaning
+func convertCommand(command, from, to string) (string, bool) {
+	command = normalize(command, from)
+	for _, equivalent := range commands {
+		if len(equivalent[to]) == 0 {
+			continue
+		}
+		for _, pattern := range equivalent[from] {
+			regex := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(normalize(pattern, from)), "%s", `(\S+)`) + "$")
+			if match := regex.FindStringSubmatch(command); match != nil {
+				output := equivalent[to][0]
+				if len(match) > 1 {
+					output = fmt.Sprintf(output, match[1])
+				}
+				return output, true
+			}
+		}
+	}
+	return "", false
+}
+
+// normalize removes the differences in whitespace and separators that do not change the meaning of a command
+func normalize(command, system string) string {
+	command = strings.Join(strings.Fields(command), " ")
+	switch system {
+	case "hyprland":
+		dispatcher, params, _ := strings.Cut(command, ",")
+		command = strings.TrimSpace(dispatcher) + "," + strings.TrimSpace(params)
+	case "niri":
+		command = strings.TrimSuffix(command, ";")
+	}
+	return command
+}
+
+// keys returns all the keys that are used within the binding, including the ones in multiples
+func keys(part izu.Part) []string {
+	kind, parts := part.Info()
+	if kind == izu.ASTString {
+		return []string{part.String()}
+	}
+
+	output := []string{}
+	parts.Iterate(func(part izu.Part) error {
+		output = append(output, keys(part)...)
+		return nil
+	})
+	return output
+}
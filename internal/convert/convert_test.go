@@ -0,0 +1,108 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/meir/izu/internal/parser"
+)
+
+func TestConvert(t *testing.T) {
+	cases := []struct {
+		input    string
+		from, to string
+
+		command string
+		flags   []string
+		losses  int
+	}{
+		{
+			input:   "super + q\n  hyprland | killactive,",
+			from:    "hyprland",
+			to:      "sway",
+			command: "kill",
+		},
+		{
+			input:   "super + 1\n  sway | workspace number 1",
+			from:    "sway",
+			to:      "hyprland",
+			command: "workspace, 1",
+		},
+		{
+			input:   "super + h\n  hyprland | movefocus,l",
+			from:    "hyprland",
+			to:      "niri",
+			command: "focus-column-left;",
+		},
+		{
+			input:   "XF86AudioPlay | sway[locked mode-media]\n  sway | workspace 3",
+			from:    "sway",
+			to:      "hyprland",
+			command: "workspace, 3",
+			flags:   []string{"l", "submap=media"},
+		},
+		{
+			input:   "super + r | hyprland[submap=\"resize mode\"]\n  hyprland | resizeactive, 10 0",
+			from:    "hyprland",
+			to:      "sway",
+			command: "",
+			flags:   []string{"mode=\"resize mode\""},
+			losses:  1,
+		},
+		{
+			input:  "super + mouse_lmb | hyprland[m]\n  hyprland | movewindow,",
+			from:   "hyprland",
+			to:     "sway",
+			losses: 3,
+		},
+		{
+			input:  "super + t\n  niri | spawn \"foot\";\n  foot",
+			from:   "niri",
+			to:     "sway",
+			losses: 0,
+		},
+	}
+
+	for case_index, c := range cases {
+		hotkeys, err := parser.Parse([]byte(c.input))
+		if err != nil {
+			t.Errorf("#%d: '%s' returned error: %v", case_index, c.input, err)
+			continue
+		}
+
+		converted, losses := Convert(hotkeys, c.from, c.to)
+		if len(losses) != c.losses {
+			t.Errorf("#%d: returned %d losses, want %d: %v", case_index, len(losses), c.losses, losses)
+		}
+
+		command, ok := converted[0].Command[c.to]
+		if c.command == "" {
+			if ok {
+				t.Errorf("#%d: expected no command for %s, got '%s'", case_index, c.to, command.String())
+			}
+		} else if !ok || command.String() != c.command {
+			t.Errorf("#%d: expected command '%s' for %s, got %v", case_index, c.command, c.to, command)
+		}
+
+		if len(c.flags) != len(converted[0].Flags[c.to]) {
+			t.Errorf("#%d: expected flags %v for %s, got %v", case_index, c.flags, c.to, converted[0].Flags[c.to])
+			continue
+		}
+		for i, flag := range c.flags {
+			if converted[0].Flags[c.to][i].String() != flag {
+				t.Errorf("#%d: expected flags %v for %s, got %v", case_index, c.flags, c.to, converted[0].Flags[c.to])
+			}
+		}
+	}
+}
+
+func TestConvertKeepsLine(t *testing.T) {
+	hotkeys, err := parser.Parse([]byte("super + w\n  foot\n\n# close the window\nsuper + q\n  hyprland | killactive,"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	converted, _ := Convert(hotkeys, "hyprland", "sway")
+	if converted[1].Line != 5 || converted[1].Description != "close the window" {
+		t.Errorf("converted hotkey has line %d and description '%s', want line 5 and 'close the window'", converted[1].Line, converted[1].Description)
+	}
+}
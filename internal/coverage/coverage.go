@@ -0,0 +1,129 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/pkg/izu"
+)
+
+// Status is how a hotkey ends up in the config of a system
+type Status int
+
+const (
+	// StatusBinding means the hotkey has a command for the system
+	StatusBinding Status = iota
+	// StatusDefault means the hotkey falls back to the default command
+	StatusDefault
+	// StatusSkipped means the hotkey has no command for the system and is left out
+	StatusSkipped
+)
+
+func (status Status) String() string {
+	switch status {
+	case StatusBinding:
+		return "binding"
+	case StatusDefault:
+		return "default"
+	default:
+		return "skipped"
+	}
+}
+
+func (status Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(status.String())
+}
+
+// Row is the coverage of a single hotkey for every system
+type Row struct {
+	Line    int               `json:"line"`
+	Hotkey  string            `json:"hotkey"`
+	Systems map[string]Status `json:"systems"`
+}
+
+// Report is the coverage of all the hotkeys in a config
+type Report struct {
+	Systems []string `json:"systems"`
+	Rows    []Row    `json:"hotkeys"`
+}
+
+// Coverage formats every hotkey with every formatter to find out which systems get a binding,
+// which fall back to the default command and which skip the hotkey
+func Coverage(hotkeys []*izu.Hotkey, formatters []*luaformatter.Formatter) (*Report, error) {
+	report := &Report{
+		Systems: []string{},
+		Rows:    []Row{},
+	}
+	for _, formatter := range formatters {
+		report.Systems = append(report.Systems, formatter.System())
+	}
+
+	for _, hotkey := range hotkeys {
+		row := Row{
+			Line:    hotkey.Line,
+			Hotkey:  hotkey.Binding.String(),
+			Systems: map[string]Status{},
+		}
+
+		for _, formatter := range formatters {
+			bindings, _, err := formatter.Expand([]*izu.Hotkey{hotkey})
+			if err != nil {
+				return nil, fmt.Errorf("failed to format hotkey on line %d for %s: %w", hotkey.Line, formatter.System(), err)
+			}
+
+			switch {
+			case len(bindings) == 0:
+				row.Systems[formatter.System()] = StatusSkipped
+			case bindings[0].Default:
+				row.Systems[formatter.System()] = StatusDefault
+			default:
+				row.Systems[formatter.System()] = StatusBinding
+			}
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	return report, nil
+}
+
+// Count returns how many hotkeys have the given status for the system
+func (report *Report) Count(system string, status Status) int {
+	count := 0
+	for _, row := range report.Rows {
+		if current, ok := row.Systems[system]; ok && current == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Text returns the report as a matrix with a row for every hotkey and a column for every system
+// followed by the totals of every system
+func (report *Report) Text() string {
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "LINE\tHOTKEY\t%s\n", strings.ToUpper(strings.Join(report.Systems, "\t")))
+	for _, row := range report.Rows {
+		statuses := []string{}
+		for _, system := range report.Systems {
+			statuses = append(statuses, row.Systems[system].String())
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\n", row.Line, row.Hotkey, strings.Join(statuses, "\t"))
+	}
+	writer.Flush()
+
+	builder.WriteString("\n")
+	for _, system := range report.Systems {
+		fmt.Fprintf(builder, "%s: %d binding, %d default, %d skipped\n",
+			system,
+			report.Count(system, StatusBinding),
+			report.Count(system, StatusDefault),
+			report.Count(system, StatusSkipped),
+		)
+	}
+	return builder.String()
+}
@@ -0,0 +1,61 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+)
+
+func TestCoverage(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	input := `super + a
+  foot
+
+super + b
+  sway | kill
+  foot
+
+super + c
+  sway | fullscreen toggle`
+
+	hotkeys, err := parser.Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formatters := []*luaformatter.Formatter{}
+	for _, system := range []string{"sway", "hyprland"} {
+		formatter, err := luaformatter.NewFormatter(system)
+		if err != nil {
+			t.Fatal(err)
+		}
+		formatters = append(formatters, formatter)
+	}
+
+	report, err := Coverage(hotkeys, formatters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Report{
+		Systems: []string{"sway", "hyprland"},
+		Rows: []Row{
+			{1, "super + a", map[string]Status{"sway": StatusDefault, "hyprland": StatusDefault}},
+			{4, "super + b", map[string]Status{"sway": StatusBinding, "hyprland": StatusDefault}},
+			{8, "super + c", map[string]Status{"sway": StatusBinding, "hyprland": StatusSkipped}},
+		},
+	}
+	if diff := deep.Equal(report, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	if count := report.Count("hyprland", StatusSkipped); count != 1 {
+		t.Errorf("hyprland has %d skipped hotkeys, want 1", count)
+	}
+}
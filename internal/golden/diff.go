@@ -0,0 +1,72 @@
+package golden
+
+import (
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around every change in a diff
+const contextLines = 2
+
+// Diff returns the lines that differ between the expected and actual text, prefixed with - for expected lines
+// and + for actual lines, with a few unchanged lines around every change, an empty string means they are equal
+func Diff(expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+	a := strings.Split(expected, "\n")
+	b := strings.Split(actual, "\n")
+
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	lines := []string{}
+	for i, j := 0, 0; i < len(a) || j < len(b); {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lengths[i+1][j] >= lengths[i][j+1]):
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+
+	// only keep the unchanged lines that are close to a change
+	output := []string{}
+	skipped := false
+	for i, line := range lines {
+		near := false
+		for j := max(0, i-contextLines); j <= min(len(lines)-1, i+contextLines); j++ {
+			if !strings.HasPrefix(lines[j], "  ") {
+				near = true
+				break
+			}
+		}
+		if !near {
+			skipped = true
+			continue
+		}
+		if skipped && len(output) > 0 {
+			output = append(output, "...")
+		}
+		skipped = false
+		output = append(output, line)
+	}
+	return strings.Join(output, "\n") + "\n"
+}
@@ -0,0 +1,127 @@
+package golden
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/internal/parser"
+)
+
+// InputExtension and ExpectedExtension are the extensions of the input config and the expected output of a case
+const (
+	InputExtension    = ".izu"
+	ExpectedExtension = ".golden"
+)
+
+// Case is a single input config with the output the formatter is expected to generate from it
+type Case struct {
+	Name     string
+	Input    string
+	Expected string
+}
+
+// Result is the outcome of formatting the input of a case
+type Result struct {
+	Case Case
+	// Diff is the difference between the expected and actual output, this is empty if they are the same
+	Diff string
+	// Updated is true if the expected output was replaced by the actual output
+	Updated bool
+	// Err is the error that stopped the case from being compared, such as an input that does not parse
+	Err error
+}
+
+// Passed returns true if the output of the formatter is the expected output
+func (result Result) Passed() bool {
+	return result.Err == nil && (result.Diff == "" || result.Updated)
+}
+
+// Cases returns the cases in the directory sorted by name, every .izu file is the input of a case
+// and the .golden file with the same name is its expected output
+func Cases(dir string) ([]Case, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	cases := []Case{}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), InputExtension)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		cases = append(cases, Case{
+			Name:     name,
+			Input:    filepath.Join(dir, entry.Name()),
+			Expected: filepath.Join(dir, name+ExpectedExtension),
+		})
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("no %s files found in %s", InputExtension, dir)
+	}
+	return cases, nil
+}
+
+// Run formats the input of every case in the directory and compares it to the expected output,
+// when update is true the expected output of the cases that differ is replaced by the actual output
+func Run(formatter *luaformatter.Formatter, dir string, update bool) ([]Result, error) {
+	cases, err := Cases(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	for _, c := range cases {
+		results = append(results, run(formatter, c, update))
+	}
+	return results, nil
+}
+
+// run formats the input of the case and compares it to the expected output
+func run(formatter *luaformatter.Formatter, c Case, update bool) Result {
+	result := Result{Case: c}
+
+	input, err := os.ReadFile(c.Input)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	hotkeys, err := parser.Parse(input)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to parse input: %w", err)
+		return result
+	}
+
+	lines, err := formatter.Format(hotkeys)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to format hotkeys: %w", err)
+		return result
+	}
+	// the output is the same as the output written by izu
+	actual := strings.Join(lines, "\n") + "\n"
+
+	expected, err := os.ReadFile(c.Expected)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && !update:
+		result.Err = fmt.Errorf("expected output %s does not exist, update the goldens to create it", c.Expected)
+		return result
+	case err != nil && !errors.Is(err, os.ErrNotExist):
+		result.Err = err
+		return result
+	}
+
+	result.Diff = Diff(string(expected), actual)
+	if result.Diff != "" && update {
+		if err := os.WriteFile(c.Expected, []byte(actual), 0o644); err != nil {
+			result.Err = fmt.Errorf("failed to update expected output: %w", err)
+			return result
+		}
+		result.Updated = true
+	}
+	return result
+}
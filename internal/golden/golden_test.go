@@ -0,0 +1,111 @@
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/pkg/izu"
+)
+
+// update replaces the golden files with the output of the formatters, run with go test ./internal/golden -update
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		expected string
+		actual   string
+		diff     string
+	}{
+		{"a\nb\n", "a\nb\n", ""},
+		{"a\nb\nc\n", "a\nx\nc\n", "  a\n- b\n+ x\n  c\n  \n"},
+		{"1\n2\n3\n4\n5\n6\n7\n8\n", "1\n2\n3\n4\n5\n6\n7\n9\n", "  6\n  7\n- 8\n+ 9\n  \n"},
+		{"a\n2\n3\n4\n5\n6\n7\nb\n", "x\n2\n3\n4\n5\n6\n7\ny\n", "- a\n+ x\n  2\n  3\n...\n  6\n  7\n- b\n+ y\n  \n"},
+	}
+
+	for i, c := range cases {
+		if diff := Diff(c.expected, c.actual); diff != c.diff {
+			t.Errorf("#%d: got diff\n%s\nwant\n%s", i, diff, c.diff)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "case.izu"), []byte("super + a\n  foot\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	formatter, err := luaformatter.NewFormatter("sway")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the expected output does not exist yet, so the case fails until the goldens are updated
+	results, err := Run(formatter, dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Passed() {
+		t.Fatalf("expected a failing case, got %+v", results)
+	}
+
+	results, err = Run(formatter, dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Passed() || !results[0].Updated {
+		t.Fatalf("expected the case to be updated, got %+v", results[0])
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "case.golden"), []byte("bindsym super+a exec alacritty\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	results, err = Run(formatter, dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Passed() || results[0].Diff != "- bindsym super+a exec alacritty\n+ bindsym super+a exec foot\n  \n" {
+		t.Errorf("unexpected diff\n%s", results[0].Diff)
+	}
+}
+
+// TestFormatters runs the golden suite in testdata for every embedded formatter
+func TestFormatters(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	names, err := izu.GetFormatterNames("lua")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range names {
+		formatter, err := luaformatter.NewFormatter(name)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+
+		results, err := Run(formatter, filepath.Join("testdata", name), *update)
+		if err != nil {
+			t.Errorf("%s: every embedded formatter needs a golden suite: %v", name, err)
+			continue
+		}
+		for _, result := range results {
+			switch {
+			case result.Err != nil:
+				t.Errorf("%s/%s: %v", name, result.Case.Name, result.Err)
+			case !result.Passed():
+				t.Errorf("%s/%s: output differs from %s\n%s", name, result.Case.Name, result.Case.Expected, result.Diff)
+			}
+		}
+	}
+}
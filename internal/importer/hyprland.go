@@ -0,0 +1,236 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/meir/izu/pkg/izu"
+)
+
+// hyprlandModifiers maps the modifier names used by hyprland to the names used in izu
+var hyprlandModifiers = map[string]string{
+	"super":   "super",
+	"win":     "super",
+	"logo":    "super",
+	"mod4":    "super",
+	"shift":   "shift",
+	"ctrl":    "ctrl",
+	"control": "ctrl",
+	"alt":     "alt",
+	"mod1":    "alt",
+	"caps":    "caps",
+	"mod2":    "mod2",
+	"mod3":    "mod3",
+	"mod5":    "mod5",
+}
+
+// hyprlandFlags are the bind flags that are understood by the hyprland formatter
+const hyprlandFlags = "lrenmtisp"
+
+// hyprlandMouseKeys maps the mouse buttons to the names used by the hyprland formatter
+var hyprlandMouseKeys = map[int]string{
+	272: "mouse_lmb",
+	273: "mouse_rmb",
+	274: "mouse_mmb",
+}
+
+// hyprlandBind matches the keyword of a bind line such as bind, binde or bindl
+var hyprlandBind = regexp.MustCompile(`^bind([a-z]*)$`)
+
+// Hyprland imports `bind` lines from hyprland configs
+// exec dispatchers become the default command, any other dispatcher becomes a `hyprland |` command
+type Hyprland struct{}
+
+// NewHyprland creates a new hyprland importer
+func NewHyprland() *Hyprland {
+	return &Hyprland{}
+}
+
+// Import parses the hyprland config and returns all the binds as hotkeys
+func (h *Hyprland) Import(data []byte) ([]*izu.Hotkey, []Warning, error) {
+	hotkeys := []*izu.Hotkey{}
+	warnings := []Warning{}
+	variables := map[string]string{}
+	submap := ""
+
+	for _, line := range splitLines(data, false) {
+		text := hyprlandComment(line.text)
+		if text == "" {
+			continue
+		}
+
+		keyword, value, ok := strings.Cut(text, "=")
+		if !ok {
+			continue
+		}
+		keyword = strings.TrimSpace(keyword)
+		value = strings.TrimSpace(value)
+
+		// variables are defined using `$name = value` and can use previously defined variables
+		if strings.HasPrefix(keyword, "$") {
+			variables[keyword] = substitute(value, variables)
+			continue
+		}
+		value = substitute(value, variables)
+
+		if keyword == "submap" {
+			submap = value
+			if submap == "reset" {
+				submap = ""
+			}
+			continue
+		}
+
+		if match := hyprlandBind.FindStringSubmatch(keyword); match != nil {
+			hotkey, hotkeyWarnings := h.hotkey(line.number, match[1], value, submap)
+			warnings = append(warnings, hotkeyWarnings...)
+			if hotkey != nil {
+				hotkeys = append(hotkeys, hotkey)
+			}
+		}
+	}
+
+	return hotkeys, warnings, nil
+}
+
+// hotkey creates a hotkey from a single hyprland bind
+func (h *Hyprland) hotkey(number int, bindflags, value, submap string) (*izu.Hotkey, []Warning) {
+	warnings := []Warning{}
+
+	// bindd has an extra description field before the dispatcher
+	fields := 4
+	if strings.Contains(bindflags, "d") {
+		fields = 5
+	}
+	args := strings.SplitN(value, ",", fields)
+	if len(args) < fields-1 {
+		return nil, append(warnings, Warning{number, fmt.Sprintf("bind '%s' does not have enough arguments and is skipped", value)})
+	}
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+	if fields == 5 {
+		warnings = append(warnings, Warning{number, fmt.Sprintf("description '%s' is dropped", args[2])})
+		args = append(args[:2], args[3:]...)
+	}
+	// the params are optional for dispatchers such as killactive
+	if len(args) == 3 {
+		args = append(args, "")
+	}
+	mods, key, dispatcher, params := args[0], args[1], args[2], args[3]
+
+	flags := []izu.Flag{}
+	for _, flag := range bindflags {
+		switch {
+		case flag == 'd':
+			continue
+		case !strings.ContainsRune(hyprlandFlags, flag):
+			warnings = append(warnings, Warning{number, fmt.Sprintf("bind flag '%c' is not supported by the hyprland formatter", flag)})
+		}
+		flags = append(flags, izu.Flag{Name: string(flag)})
+	}
+	if submap != "" {
+		flags = append(flags, izu.Flag{Name: "submap", Value: submap})
+	}
+
+	// modifiers can be separated by spaces, underscores or nothing at all such as SUPERSHIFT
+	keys := []string{}
+	for _, modifier := range strings.FieldsFunc(strings.ToLower(mods), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '+'
+	}) {
+		matched, rest := hyprlandModifier(modifier)
+		if rest != "" {
+			warnings = append(warnings, Warning{number, fmt.Sprintf("unknown modifier '%s'", rest)})
+			matched = append(matched, rest)
+		}
+		keys = append(keys, matched...)
+	}
+
+	key, ok := hyprlandKey(key)
+	if !ok {
+		warnings = append(warnings, Warning{number, fmt.Sprintf("key '%s' has no izu equivalent", key)})
+	}
+	keys = append(keys, key)
+
+	hotkey := &izu.Hotkey{
+		Binding: newBinding(keys),
+		Flags:   map[string][]izu.Flag{},
+		Command: map[string]izu.Part{},
+		Line:    number,
+	}
+	if len(flags) > 0 {
+		hotkey.Flags["hyprland"] = flags
+	}
+
+	command := ""
+	if dispatcher == "exec" && params != "" {
+		hotkey.Command["default"] = newCommand(params)
+		command = params
+	} else {
+		command = strings.TrimSpace(dispatcher + ", " + params)
+		hotkey.Command["hyprland"] = newCommand(command)
+	}
+	warnings = append(warnings, commandWarnings(number, command)...)
+
+	return hotkey, warnings
+}
+
+// hyprlandComment strips the comment from the line, ## is used to escape a #
+func hyprlandComment(text string) string {
+	output := strings.Builder{}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '#' {
+			if i+1 < len(text) && text[i+1] == '#' {
+				output.WriteByte('#')
+				i++
+				continue
+			}
+			break
+		}
+		output.WriteByte(text[i])
+	}
+	return strings.TrimSpace(output.String())
+}
+
+// hyprlandModifier splits a modifier string such as "supershift" into the izu modifiers
+// the part that could not be matched is returned as the rest
+func hyprlandModifier(text string) ([]string, string) {
+	output := []string{}
+Loop:
+	for text != "" {
+		// try the longest names first so "control" is not matched as "ctrl" + "ol"
+		for length := len(text); length > 0; length-- {
+			if modifier, ok := hyprlandModifiers[text[:length]]; ok {
+				output = append(output, modifier)
+				text = text[length:]
+				continue Loop
+			}
+		}
+		break
+	}
+	return output, text
+}
+
+// hyprlandKey converts a hyprland key to its izu name, mouse:272 becomes mouse_lmb
+// keys that cannot be used in izu return false
+func hyprlandKey(key string) (string, bool) {
+	button, ok := strings.CutPrefix(key, "mouse:")
+	if !ok {
+		return key, !strings.Contains(key, ":")
+	}
+
+	number, err := strconv.Atoi(button)
+	if err != nil {
+		return key, false
+	}
+	if name, ok := hyprlandMouseKeys[number]; ok {
+		return name, true
+	}
+	// the hyprland formatter maps mouse_x1 to mouse:275 and so on
+	if number > 274 && number < 284 {
+		return fmt.Sprintf("mouse_x%d", number-274), true
+	}
+	return key, false
+}
@@ -0,0 +1,116 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/pkg/izu"
+)
+
+func TestHyprland(t *testing.T) {
+	cases := []struct {
+		input string
+
+		output   []string
+		warnings int
+	}{
+		{
+			input: `$mainMod = SUPER
+bind = $mainMod, Q, exec, kitty # terminal`,
+			output: []string{"super + Q\n  kitty\n"},
+		},
+		{
+			input:  `bind = SUPER_SHIFT, C, killactive,`,
+			output: []string{"super + shift + C\n  hyprland | killactive,\n"},
+		},
+		{
+			input:  `binde = CTRLALT, right, resizeactive, 10 0`,
+			output: []string{"ctrl + alt + right | hyprland[e]\n  hyprland | resizeactive, 10 0\n"},
+		},
+		{
+			input: `bindm = SUPER, mouse:272, movewindow
+bindm = SUPER, mouse:276, resizewindow`,
+			output: []string{
+				"super + mouse_lmb | hyprland[m]\n  hyprland | movewindow,\n",
+				"super + mouse_x2 | hyprland[m]\n  hyprland | resizewindow,\n",
+			},
+		},
+		{
+			input: `submap = resize
+bind = , escape, submap, reset
+submap = reset`,
+			output: []string{"escape | hyprland[submap=resize]\n  hyprland | submap, reset\n"},
+		},
+		{
+			input: `bindd = SUPER, R, Launcher, exec, rofi -show drun
+bind = , code:10, workspace, 1`,
+			output: []string{
+				"super + R\n  rofi -show drun\n",
+				"code:10\n  hyprland | workspace, 1\n",
+			},
+			warnings: 2,
+		},
+	}
+
+	for case_index, c := range cases {
+		hotkeys, warnings, err := NewHyprland().Import([]byte(c.input))
+		if err != nil {
+			t.Errorf("#%d: '%s' returned error: %v", case_index, c.input, err)
+			continue
+		}
+
+		if len(warnings) != c.warnings {
+			t.Errorf("#%d: returned %d warnings, want %d: %v", case_index, len(warnings), c.warnings, warnings)
+		}
+
+		if len(hotkeys) != len(c.output) {
+			t.Errorf("#%d: '%s' returned %d hotkeys, want %d", case_index, c.input, len(hotkeys), len(c.output))
+			continue
+		}
+
+		for i, hotkey := range hotkeys {
+			if hotkey.String() != c.output[i] {
+				t.Errorf("#%d: got %q, want %q", case_index, hotkey.String(), c.output[i])
+			}
+		}
+	}
+}
+
+func TestHyprlandRoundTrip(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	input := `bindl = , XF86AudioPlay, exec, playerctl play-pause
+bindm = SUPER, mouse:272, movewindow,
+submap = resize
+binde = , Right, resizeactive, 10 0
+submap = reset`
+	expected := []string{
+		"bindl = , XF86AudioPlay, exec, playerctl play-pause",
+		"bindm = Super, mouse:272, movewindow,",
+		"submap = resize",
+		"binde = , Right, resizeactive, 10 0",
+		"submap = reset",
+	}
+
+	hotkeys, _, err := NewHyprland().Import([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formatter, err := luaformatter.NewFormatter("hyprland")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := formatter.Format(hotkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(expected, "\n"))
+	}
+}
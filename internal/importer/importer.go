@@ -0,0 +1,161 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+)
+
+// Importer is the interface that should be implemented for every hotkey daemon that can be imported
+// It converts the config of that daemon into izu hotkeys
+type Importer interface {
+	Import([]byte) ([]*izu.Hotkey, []Warning, error)
+}
+
+// Warning describes something in the imported config that could not be converted as-is
+type Warning struct {
+	Line    int
+	Message string
+}
+
+// String returns the warning formatted as "line N: message"
+func (w Warning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+}
+
+// importers is a map of all the importers by the name of the system they import
+var importers = map[string]func() Importer{
+	"sway":     func() Importer { return NewSway() },
+	"i3":       func() Importer { return NewSway() },
+	"hyprland": func() Importer { return NewHyprland() },
+	"niri":     func() Importer { return NewNiri() },
+}
+
+// systems maps importers to the system their specific commands are written for, if it is not the same name
+var systems = map[string]string{
+	"i3": "sway",
+}
+
+// Get returns the importer for the given system
+func Get(system string) (Importer, error) {
+	if importer, ok := importers[system]; ok {
+		return importer(), nil
+	}
+	return nil, fmt.Errorf("no importer for '%s', available importers are: %s", system, strings.Join(Names(), ", "))
+}
+
+// System returns the system that the hotkeys of the importer use for their system specific commands and flags
+func System(importer string) string {
+	if system, ok := systems[importer]; ok {
+		return system
+	}
+	return importer
+}
+
+// Names returns the names of all the available importers
+func Names() []string {
+	names := []string{}
+	for name := range importers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// --- helpers ---
+
+// line is a single logical line of a config file with the line number it started on
+type line struct {
+	number int
+	text   string
+}
+
+// splitLines splits the data into trimmed lines
+// if continuation is true, lines ending with a backslash are joined with the next line
+func splitLines(data []byte, continuation bool) []line {
+	output := []line{}
+	current := ""
+	start := 0
+	for i, text := range strings.Split(string(data), "\n") {
+		text = strings.TrimSpace(text)
+		if current == "" {
+			start = i + 1
+		}
+
+		if continuation && strings.HasSuffix(text, "\\") {
+			current += strings.TrimSpace(strings.TrimSuffix(text, "\\")) + " "
+			continue
+		}
+
+		output = append(output, line{start, strings.TrimSpace(current + text)})
+		current = ""
+	}
+	if current != "" {
+		output = append(output, line{start, strings.TrimSpace(current)})
+	}
+	return output
+}
+
+// cutWord splits the first whitespace separated word from the rest of the text
+func cutWord(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if index := strings.IndexAny(text, " \t"); index >= 0 {
+		return text[:index], strings.TrimSpace(text[index+1:])
+	}
+	return text, ""
+}
+
+// unquote removes surrounding double or single quotes from the text
+func unquote(text string) string {
+	if len(text) >= 2 && (text[0] == '"' || text[0] == '\'') && text[len(text)-1] == text[0] {
+		return text[1 : len(text)-1]
+	}
+	return text
+}
+
+// isFlagValue checks if the value can be used as the name of a flag in the izu syntax, which only allows [A-Za-z0-9_-]+
+func isFlagValue(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, char := range value {
+		switch {
+		case char >= 'A' && char <= 'Z':
+		case char >= 'a' && char <= 'z':
+		case char >= '0' && char <= '9':
+		case char == '_' || char == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newBinding creates a binding part the same way the parser would for "key + key + key"
+func newBinding(keys []string) izu.Part {
+	binding := parser.NewPartBinding(" + ")
+	for _, key := range keys {
+		binding.Append(parser.NewPartSingle(parser.NewPartString(key)))
+	}
+	return binding
+}
+
+// newCommand creates a command part containing the command as-is
+func newCommand(command string) izu.Part {
+	return parser.NewPartBinding("", parser.NewPartString(command))
+}
+
+// commandWarnings checks if the command would be parsed differently when the imported hotkeys are read back by izu
+func commandWarnings(number int, command string) []Warning {
+	warnings := []Warning{}
+	if strings.ContainsAny(command, "{}") {
+		warnings = append(warnings, Warning{number, fmt.Sprintf("command '%s' contains braces, which izu will read as a multiple", command)})
+	}
+	if before, _, ok := strings.Cut(command, "|"); ok && len(strings.Fields(before)) == 1 {
+		warnings = append(warnings, Warning{number, fmt.Sprintf("command '%s' contains a pipe after its first word, which izu will read as a system", command)})
+	}
+	return warnings
+}
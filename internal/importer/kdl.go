@@ -0,0 +1,328 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// kdlNode is a single node of a KDL document, such as `Mod+T repeat=false { spawn "foot"; }`
+type kdlNode struct {
+	name       string
+	line       int
+	args       []kdlValue
+	properties []kdlProperty
+	children   []*kdlNode
+}
+
+// kdlValue is a value of an argument or property, quoted is used to write it back the same way
+type kdlValue struct {
+	text   string
+	quoted bool
+}
+
+// kdlProperty is a key=value property of a node
+type kdlProperty struct {
+	name  string
+	value kdlValue
+}
+
+// String returns the value as it would be written in KDL
+func (v kdlValue) String() string {
+	if !v.quoted {
+		return v.text
+	}
+	replacer := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n", "\t", "\\t")
+	return "\"" + replacer.Replace(v.text) + "\""
+}
+
+// String returns the node without its children as it would be written in KDL
+func (n *kdlNode) String() string {
+	output := []string{n.name}
+	for _, arg := range n.args {
+		output = append(output, arg.String())
+	}
+	for _, property := range n.properties {
+		output = append(output, property.name+"="+property.value.String())
+	}
+	return strings.Join(output, " ")
+}
+
+// kdlParser is a small KDL reader, it only supports what is needed to read configs such as the one of niri
+type kdlParser struct {
+	data []rune
+	pos  int
+	line int
+}
+
+// parseKDL parses the data into a list of the top level nodes
+func parseKDL(data []byte) ([]*kdlNode, error) {
+	parser := &kdlParser{
+		data: []rune(string(data)),
+		line: 1,
+	}
+	return parser.nodes(false)
+}
+
+// peek returns the rune at the offset from the current position or 0 at the end of the data
+func (p *kdlParser) peek(offset int) rune {
+	if p.pos+offset >= len(p.data) {
+		return 0
+	}
+	return p.data[p.pos+offset]
+}
+
+// next moves to the next rune and keeps track of the line number
+func (p *kdlParser) next() rune {
+	char := p.peek(0)
+	if char == '\n' {
+		p.line++
+	}
+	p.pos++
+	return char
+}
+
+// errorf returns an error with the current line
+func (p *kdlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips whitespace, comments and escaped newlines
+// if newlines is true, newlines and semicolons are skipped too
+func (p *kdlParser) skipSpace(newlines bool) error {
+	for p.pos < len(p.data) {
+		char := p.peek(0)
+		switch {
+		case char == '\n' || char == ';':
+			if !newlines {
+				return nil
+			}
+			p.next()
+		case char == '\\':
+			// line continuation, skip everything up to and including the newline
+			for p.pos < len(p.data) && p.next() != '\n' {
+			}
+		case unicode.IsSpace(char):
+			p.next()
+		case char == '/' && p.peek(1) == '/':
+			for p.pos < len(p.data) && p.peek(0) != '\n' {
+				p.next()
+			}
+		case char == '/' && p.peek(1) == '*':
+			// block comments can be nested
+			depth := 0
+			for {
+				switch {
+				case p.pos >= len(p.data):
+					return p.errorf("unclosed block comment")
+				case p.peek(0) == '/' && p.peek(1) == '*':
+					depth++
+					p.next()
+				case p.peek(0) == '*' && p.peek(1) == '/':
+					depth--
+					p.next()
+				}
+				p.next()
+				if depth == 0 {
+					break
+				}
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// nodes parses a list of nodes, if closing is true the list has to end with a }
+func (p *kdlParser) nodes(closing bool) ([]*kdlNode, error) {
+	nodes := []*kdlNode{}
+	for {
+		if err := p.skipSpace(true); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.pos >= len(p.data):
+			if closing {
+				return nil, p.errorf("expected '}' before the end of the file")
+			}
+			return nodes, nil
+		case p.peek(0) == '}':
+			if !closing {
+				return nil, p.errorf("unexpected '}'")
+			}
+			p.next()
+			return nodes, nil
+		case p.peek(0) == '/' && p.peek(1) == '-':
+			// slashdash comments out the entire next node
+			p.next()
+			p.next()
+			if err := p.skipSpace(false); err != nil {
+				return nil, err
+			}
+			if _, err := p.node(); err != nil {
+				return nil, err
+			}
+		default:
+			node, err := p.node()
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+	}
+}
+
+// node parses a single node with its arguments, properties and children
+func (p *kdlParser) node() (*kdlNode, error) {
+	node := &kdlNode{line: p.line}
+	name, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	node.name = name.text
+
+	for {
+		if err := p.skipSpace(false); err != nil {
+			return nil, err
+		}
+
+		discard := false
+		if p.peek(0) == '/' && p.peek(1) == '-' {
+			// slashdash comments out the next argument, property or children block
+			p.next()
+			p.next()
+			discard = true
+			if err := p.skipSpace(false); err != nil {
+				return nil, err
+			}
+		}
+
+		switch char := p.peek(0); {
+		case p.pos >= len(p.data), char == '}':
+			return node, nil
+		case char == '\n' || char == ';':
+			p.next()
+			return node, nil
+		case char == '{':
+			p.next()
+			children, err := p.nodes(true)
+			if err != nil {
+				return nil, err
+			}
+			if !discard {
+				node.children = append(node.children, children...)
+			}
+		default:
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+
+			if p.peek(0) == '=' && !value.quoted {
+				p.next()
+				property, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				if !discard {
+					node.properties = append(node.properties, kdlProperty{value.text, property})
+				}
+				continue
+			}
+
+			if !discard {
+				node.args = append(node.args, value)
+			}
+		}
+	}
+}
+
+// value parses a quoted string, raw string or bare value
+func (p *kdlParser) value() (kdlValue, error) {
+	// type annotations such as (u8)10 are ignored
+	if p.peek(0) == '(' {
+		for p.pos < len(p.data) && p.next() != ')' {
+		}
+	}
+
+	switch {
+	case p.peek(0) == '"':
+		return p.quoted()
+	case p.peek(0) == 'r' && (p.peek(1) == '"' || p.peek(1) == '#'):
+		return p.raw()
+	}
+
+	start := p.pos
+	for p.pos < len(p.data) {
+		char := p.peek(0)
+		if unicode.IsSpace(char) || strings.ContainsRune("{}();=\"\\", char) {
+			break
+		}
+		if char == '/' && (p.peek(1) == '/' || p.peek(1) == '*' || p.peek(1) == '-') {
+			break
+		}
+		p.next()
+	}
+
+	if start == p.pos {
+		return kdlValue{}, p.errorf("unexpected '%c'", p.peek(0))
+	}
+	return kdlValue{text: string(p.data[start:p.pos])}, nil
+}
+
+// quoted parses a string between double quotes with escape characters
+func (p *kdlParser) quoted() (kdlValue, error) {
+	p.next()
+	output := strings.Builder{}
+	escapes := map[rune]rune{'n': '\n', 't': '\t', 'r': '\r', '"': '"', '\\': '\\', '/': '/'}
+	for {
+		if p.pos >= len(p.data) {
+			return kdlValue{}, p.errorf("unclosed string")
+		}
+
+		char := p.next()
+		switch char {
+		case '"':
+			return kdlValue{output.String(), true}, nil
+		case '\\':
+			escaped := p.next()
+			if replacement, ok := escapes[escaped]; ok {
+				output.WriteRune(replacement)
+			} else {
+				output.WriteRune('\\')
+				output.WriteRune(escaped)
+			}
+		default:
+			output.WriteRune(char)
+		}
+	}
+}
+
+// raw parses a raw string such as r"C:\path" or r#"a "quoted" string"#
+func (p *kdlParser) raw() (kdlValue, error) {
+	p.next()
+	hashes := 0
+	for p.peek(0) == '#' {
+		hashes++
+		p.next()
+	}
+	if p.next() != '"' {
+		return kdlValue{}, p.errorf("expected '\"' in raw string")
+	}
+
+	closing := "\"" + strings.Repeat("#", hashes)
+	start := p.pos
+	for p.pos < len(p.data) {
+		if strings.HasPrefix(string(p.data[p.pos:]), closing) {
+			value := string(p.data[start:p.pos])
+			for range closing {
+				p.next()
+			}
+			return kdlValue{value, true}, nil
+		}
+		p.next()
+	}
+	return kdlValue{}, p.errorf("unclosed raw string")
+}
@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/meir/izu/pkg/izu"
+)
+
+// niriModifiers maps the modifier names used by niri to the names used in izu
+// Mod is super when niri runs on a tty, which is the case for any config worth importing
+var niriModifiers = map[string]string{
+	"mod":     "super",
+	"super":   "super",
+	"win":     "super",
+	"shift":   "shift",
+	"ctrl":    "ctrl",
+	"control": "ctrl",
+	"alt":     "alt",
+}
+
+// niriMouseKeys maps the mouse buttons to the names used by the niri formatter
+var niriMouseKeys = map[string]string{
+	"mouseleft":   "mouse_lmb",
+	"mouseright":  "mouse_rmb",
+	"mousemiddle": "mouse_mmb",
+}
+
+// Niri imports the `binds {}` section of niri configs
+// every bind gets a `niri |` command with the action, plain spawn actions also become the default command
+type Niri struct{}
+
+// NewNiri creates a new niri importer
+func NewNiri() *Niri {
+	return &Niri{}
+}
+
+// Import parses the niri config and returns all the binds as hotkeys
+func (n *Niri) Import(data []byte) ([]*izu.Hotkey, []Warning, error) {
+	nodes, err := parseKDL(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hotkeys := []*izu.Hotkey{}
+	warnings := []Warning{}
+	for _, node := range nodes {
+		if node.name != "binds" {
+			continue
+		}
+
+		for _, bind := range node.children {
+			hotkey, hotkeyWarnings := n.hotkey(bind)
+			warnings = append(warnings, hotkeyWarnings...)
+			if hotkey != nil {
+				hotkeys = append(hotkeys, hotkey)
+			}
+		}
+	}
+
+	return hotkeys, warnings, nil
+}
+
+// hotkey creates a hotkey from a single bind node such as `Mod+T { spawn "foot"; }`
+func (n *Niri) hotkey(bind *kdlNode) (*izu.Hotkey, []Warning) {
+	warnings := []Warning{}
+	if len(bind.children) == 0 {
+		return nil, append(warnings, Warning{bind.line, fmt.Sprintf("bind '%s' has no action and is skipped", bind.name)})
+	}
+	if len(bind.children) > 1 {
+		warnings = append(warnings, Warning{bind.line, fmt.Sprintf("bind '%s' has more than one action, only the first is used", bind.name)})
+	}
+	action := bind.children[0]
+
+	// properties are written as flags, allow-when-locked=true becomes allow-when-locked,
+	// repeat=false becomes no-repeat and properties with a value such as cooldown-ms=150 keep their value
+	flags := []izu.Flag{}
+	for _, property := range bind.properties {
+		flag := izu.Flag{Name: property.name}
+		switch value := property.value.text; {
+		case property.value.quoted:
+			flag.Value = value
+		case value == "true" || value == "#true":
+		case value == "false" || value == "#false":
+			flag.Name = "no-" + property.name
+		default:
+			flag.Value = value
+		}
+
+		if !isFlagValue(flag.Name) {
+			warnings = append(warnings, Warning{bind.line, fmt.Sprintf("property '%s' cannot be written as a flag and is dropped", property.name)})
+			continue
+		}
+		flags = append(flags, flag)
+	}
+
+	keys := []string{}
+	for _, key := range strings.Split(bind.name, "+") {
+		if modifier, ok := niriModifiers[strings.ToLower(key)]; ok {
+			key = modifier
+		} else if mouse, ok := niriMouseKeys[strings.ToLower(key)]; ok {
+			key = mouse
+		}
+		keys = append(keys, key)
+	}
+
+	hotkey := &izu.Hotkey{
+		Binding: newBinding(keys),
+		Flags:   map[string][]izu.Flag{},
+		Command: map[string]izu.Part{},
+		Line:    bind.line,
+	}
+	if len(flags) > 0 {
+		hotkey.Flags["niri"] = flags
+	}
+
+	command := action.String() + ";"
+	hotkey.Command["niri"] = newCommand(command)
+	warnings = append(warnings, commandWarnings(bind.line, command)...)
+
+	if spawn, ok := niriSpawn(action); ok {
+		hotkey.Command["default"] = newCommand(spawn)
+	}
+
+	return hotkey, warnings
+}
+
+// niriSpawn returns the shell command for spawn and spawn-sh actions without any properties
+func niriSpawn(action *kdlNode) (string, bool) {
+	if len(action.args) == 0 || len(action.properties) > 0 || len(action.children) > 0 {
+		return "", false
+	}
+
+	switch action.name {
+	case "spawn":
+		args := []string{}
+		for _, arg := range action.args {
+			args = append(args, izu.ShellQuote(arg.text))
+		}
+		return strings.Join(args, " "), true
+	case "spawn-sh":
+		if len(action.args) == 1 {
+			return action.args[0].text, true
+		}
+	}
+	return "", false
+}
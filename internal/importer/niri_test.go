@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"testing"
+)
+
+func TestNiri(t *testing.T) {
+	cases := []struct {
+		input string
+
+		output   []string
+		warnings int
+	}{
+		{
+			input:  `binds { Mod+Shift+H { focus-column-left; } }`,
+			output: []string{"super + shift + H\n  niri | focus-column-left;\n"},
+		},
+		{
+			input: `binds {
+    Mod+T hotkey-overlay-title="Open a Terminal" { spawn "alacritty"; }
+}`,
+			output: []string{"super + T | niri[hotkey-overlay-title=\"Open a Terminal\"]\n  niri | spawn \"alacritty\";\n  alacritty\n"},
+		},
+		{
+			input: `binds {
+    // volume keys
+    XF86AudioRaiseVolume allow-when-locked=true repeat=false { spawn "wpctl" "set-volume" "@DEFAULT_AUDIO_SINK@" "0.1+"; }
+    Mod+WheelScrollDown cooldown-ms=150 { focus-workspace-down; }
+}`,
+			output: []string{
+				"XF86AudioRaiseVolume | niri[allow-when-locked no-repeat]\n  niri | spawn \"wpctl\" \"set-volume\" \"@DEFAULT_AUDIO_SINK@\" \"0.1+\";\n  wpctl set-volume @DEFAULT_AUDIO_SINK@ 0.1+\n",
+				"super + WheelScrollDown | niri[cooldown-ms=150]\n  niri | focus-workspace-down;\n",
+			},
+		},
+		{
+			input: `binds {
+    /-Mod+X { quit; }
+    Mod+P { spawn "sh" "-c" "grim - | wl-copy"; }
+    Mod+S {
+        spawn-sh r#"notify-send "hi there""#
+    }
+    /* Mod+Q { close-window; } */
+    Ctrl+MouseLeft { focus-column-left; }
+}`,
+			output: []string{
+				"super + P\n  niri | spawn \"sh\" \"-c\" \"grim - | wl-copy\";\n  sh -c 'grim - | wl-copy'\n",
+				"super + S\n  niri | spawn-sh \"notify-send \\\"hi there\\\"\";\n  notify-send \"hi there\"\n",
+				"ctrl + mouse_lmb\n  niri | focus-column-left;\n",
+			},
+		},
+	}
+
+	for case_index, c := range cases {
+		hotkeys, warnings, err := NewNiri().Import([]byte(c.input))
+		if err != nil {
+			t.Errorf("#%d: '%s' returned error: %v", case_index, c.input, err)
+			continue
+		}
+
+		if len(warnings) != c.warnings {
+			t.Errorf("#%d: returned %d warnings, want %d: %v", case_index, len(warnings), c.warnings, warnings)
+		}
+
+		if len(hotkeys) != len(c.output) {
+			t.Errorf("#%d: '%s' returned %d hotkeys, want %d", case_index, c.input, len(hotkeys), len(c.output))
+			continue
+		}
+
+		for i, hotkey := range hotkeys {
+			if hotkey.String() != c.output[i] {
+				t.Errorf("#%d: got %q, want %q", case_index, hotkey.String(), c.output[i])
+			}
+		}
+	}
+}
+
+func TestNiriInvalid(t *testing.T) {
+	for _, input := range []string{
+		`binds { Mod+T { spawn "foot; } }`,
+		`binds { Mod+T { spawn "foot"; }`,
+		`}`,
+	} {
+		if _, _, err := NewNiri().Import([]byte(input)); err == nil {
+			t.Errorf("'%s' should return an error", input)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/meir/izu/pkg/izu"
+)
+
+// swayModifiers maps the modifier names used by sway and i3 to the names used in izu
+var swayModifiers = map[string]string{
+	"mod1":    "alt",
+	"mod2":    "mod2",
+	"mod3":    "mod3",
+	"mod4":    "super",
+	"mod5":    "mod5",
+	"shift":   "shift",
+	"control": "ctrl",
+	"ctrl":    "ctrl",
+	"alt":     "alt",
+	"super":   "super",
+	"lock":    "lock",
+}
+
+// Sway imports `bindsym` and `bindcode` lines from sway and i3 configs
+// exec commands become the default command, any other command becomes a `sway |` command
+type Sway struct{}
+
+// NewSway creates a new sway/i3 importer
+func NewSway() *Sway {
+	return &Sway{}
+}
+
+// Import parses the sway config and returns all the bindings as hotkeys
+func (s *Sway) Import(data []byte) ([]*izu.Hotkey, []Warning, error) {
+	hotkeys := []*izu.Hotkey{}
+	warnings := []Warning{}
+	variables := map[string]string{}
+	modes := []string{}
+	// block is the bind command and its options while inside of a `bindsym --release { ... }` block
+	block := ""
+
+	for _, line := range splitLines(data, true) {
+		text := line.text
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		// variables are defined using `set $name value` and can use previously defined variables
+		if word, rest := cutWord(text); word == "set" {
+			name, value := cutWord(rest)
+			variables[name] = substitute(value, variables)
+			continue
+		}
+		text = substitute(text, variables)
+
+		if text == "}" {
+			switch {
+			case block != "":
+				block = ""
+			case len(modes) > 0:
+				modes = modes[:len(modes)-1]
+			default:
+				return nil, nil, fmt.Errorf("line %d: unexpected '}'", line.number)
+			}
+			continue
+		}
+
+		if block != "" {
+			text = block + " " + text
+		}
+
+		word, rest := cutWord(text)
+		switch word {
+		case "mode":
+			// only mode blocks are of interest, `mode` can also be used as a command
+			if !strings.HasSuffix(rest, "{") {
+				continue
+			}
+			rest = strings.TrimSpace(strings.TrimSuffix(rest, "{"))
+			if option, name := cutWord(rest); option == "--pango_markup" {
+				rest = name
+			}
+			modes = append(modes, unquote(rest))
+
+		case "bindsym", "bindcode":
+			options, combo, command := swayBinding(rest)
+			if combo == "{" {
+				block = strings.Join(append([]string{word}, options...), " ")
+				continue
+			}
+
+			hotkey, hotkeyWarnings := s.hotkey(line.number, word, options, combo, command, modes)
+			warnings = append(warnings, hotkeyWarnings...)
+			if hotkey != nil {
+				hotkeys = append(hotkeys, hotkey)
+			}
+
+		case "bindswitch", "bindgesture", "bindswitchsym":
+			warnings = append(warnings, Warning{line.number, fmt.Sprintf("'%s' bindings are not supported by izu", word)})
+
+		case "include":
+			warnings = append(warnings, Warning{line.number, fmt.Sprintf("included file '%s' is not imported", rest)})
+		}
+	}
+
+	if block != "" || len(modes) > 0 {
+		return nil, nil, fmt.Errorf("unexpected end of config, a block was not closed")
+	}
+
+	return hotkeys, warnings, nil
+}
+
+// hotkey creates a hotkey from a single sway binding
+func (s *Sway) hotkey(number int, kind string, options []string, combo, command string, modes []string) (*izu.Hotkey, []Warning) {
+	warnings := []Warning{}
+	if combo == "" || command == "" {
+		return nil, append(warnings, Warning{number, "binding without a key or command is skipped"})
+	}
+
+	flags := []izu.Flag{}
+	if kind == "bindcode" {
+		flags = append(flags, izu.Flag{Name: "bindcode"})
+	}
+	for _, option := range options {
+		// options with a value such as --input-device=1:1:keyboard become key/value flags
+		name, value, _ := strings.Cut(strings.TrimPrefix(option, "--"), "=")
+		if !isFlagValue(name) {
+			warnings = append(warnings, Warning{number, fmt.Sprintf("option '%s' cannot be written as a flag and is dropped", option)})
+			continue
+		}
+		flags = append(flags, izu.Flag{Name: name, Value: unquote(value)})
+	}
+	if len(modes) > 0 {
+		flags = append(flags, izu.Flag{Name: "mode", Value: modes[len(modes)-1]})
+	}
+
+	keys := []string{}
+	for _, key := range strings.Split(combo, "+") {
+		if modifier, ok := swayModifiers[strings.ToLower(key)]; ok {
+			key = modifier
+		}
+		if strings.HasPrefix(key, "$") {
+			warnings = append(warnings, Warning{number, fmt.Sprintf("variable '%s' is not defined", key)})
+		}
+		keys = append(keys, key)
+	}
+
+	hotkey := &izu.Hotkey{
+		Binding: newBinding(keys),
+		Flags:   map[string][]izu.Flag{},
+		Command: map[string]izu.Part{},
+		Line:    number,
+	}
+	if len(flags) > 0 {
+		hotkey.Flags["sway"] = flags
+	}
+
+	system := "sway"
+	if exec, ok := swayExec(command); ok {
+		system = "default"
+		command = exec
+	}
+	hotkey.Command[system] = newCommand(command)
+	warnings = append(warnings, commandWarnings(number, command)...)
+
+	return hotkey, warnings
+}
+
+// swayBinding splits the arguments of a bindsym/bindcode into its options, key combination and command
+func swayBinding(text string) ([]string, string, string) {
+	options := []string{}
+	for {
+		word, rest := cutWord(text)
+		if !strings.HasPrefix(word, "--") {
+			break
+		}
+		options = append(options, word)
+		text = rest
+	}
+
+	combo, command := cutWord(text)
+	return options, combo, command
+}
+
+// swayExec returns the command run by an exec if the given command is a single exec
+func swayExec(command string) (string, bool) {
+	word, rest := cutWord(command)
+	if word != "exec" {
+		return "", false
+	}
+	if option, args := cutWord(rest); option == "--no-startup-id" {
+		rest = args
+	}
+
+	// sway splits commands on ; and , so if there are any outside of quotes this is a list of commands
+	quote := rune(0)
+	for _, char := range rest {
+		switch {
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote == 0 && (char == '"' || char == '\''):
+			quote = char
+		case quote == 0 && (char == ';' || char == ','):
+			return "", false
+		}
+	}
+
+	rest = unquote(rest)
+	return rest, rest != ""
+}
+
+// substitute replaces all the known $variables in the text, longest names first so $mod does not replace part of $modifier
+func substitute(text string, variables map[string]string) string {
+	names := []string{}
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+
+	for _, name := range names {
+		text = strings.ReplaceAll(text, name, variables[name])
+	}
+	return text
+}
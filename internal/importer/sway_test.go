@@ -0,0 +1,101 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSway(t *testing.T) {
+	cases := []struct {
+		input string
+
+		output   []string
+		warnings int
+	}{
+		{
+			input: `set $mod Mod4
+set $term foot
+bindsym $mod+Return exec $term`,
+			output: []string{"super + Return\n  foot\n"},
+		},
+		{
+			input:  `bindsym Mod4+Shift+q kill`,
+			output: []string{"super + shift + q\n  sway | kill\n"},
+		},
+		{
+			input:  `bindsym --release --locked Mod1+p exec --no-startup-id "playerctl play"`,
+			output: []string{"alt + p | sway[release locked]\n  playerctl play\n"},
+		},
+		{
+			input:  `bindsym Control+p exec playerctl play; exec notify-send hi`,
+			output: []string{"ctrl + p\n  sway | exec playerctl play; exec notify-send hi\n"},
+		},
+		{
+			input: `bindcode --to-code Mod4+49 exec foo \
+  --bar`,
+			output: []string{"super + 49 | sway[bindcode to-code]\n  foo --bar\n"},
+		},
+		{
+			input: `bindsym --locked {
+  XF86AudioPlay exec playerctl play
+  XF86AudioPause exec playerctl pause
+}`,
+			output: []string{
+				"XF86AudioPlay | sway[locked]\n  playerctl play\n",
+				"XF86AudioPause | sway[locked]\n  playerctl pause\n",
+			},
+		},
+		{
+			input: `mode "resize" {
+  bindsym h resize shrink width 10px
+  bindsym Return mode "default"
+}`,
+			output: []string{
+				"h | sway[mode=resize]\n  sway | resize shrink width 10px\n",
+				"Return | sway[mode=resize]\n  sway | mode \"default\"\n",
+			},
+		},
+		{
+			input: `mode "System (l) lock" {
+  bindsym l exec swaylock
+}
+bindswitch lid:on exec swaylock
+bindsym --input-device=1:1:kb Mod4+x exec x`,
+			output: []string{
+				"l | sway[mode=\"System (l) lock\"]\n  swaylock\n",
+				"super + x | sway[input-device=1:1:kb]\n  x\n",
+			},
+			warnings: 1,
+		},
+	}
+
+	for case_index, c := range cases {
+		hotkeys, warnings, err := NewSway().Import([]byte(c.input))
+		if err != nil {
+			t.Errorf("#%d: '%s' returned error: %v", case_index, c.input, err)
+			continue
+		}
+
+		if len(warnings) != c.warnings {
+			t.Errorf("#%d: returned %d warnings, want %d: %v", case_index, len(warnings), c.warnings, warnings)
+		}
+
+		if len(hotkeys) != len(c.output) {
+			t.Errorf("#%d: '%s' returned %d hotkeys, want %d", case_index, c.input, len(hotkeys), len(c.output))
+			continue
+		}
+
+		for i, hotkey := range hotkeys {
+			if hotkey.String() != c.output[i] {
+				t.Errorf("#%d: got %q, want %q", case_index, hotkey.String(), c.output[i])
+			}
+		}
+	}
+}
+
+func TestSwayUnclosedBlock(t *testing.T) {
+	_, _, err := NewSway().Import([]byte("mode \"resize\" {\n  bindsym h resize shrink width 10px"))
+	if err == nil || !strings.Contains(err.Error(), "not closed") {
+		t.Errorf("expected an error for an unclosed block, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package keyboard
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Key is a single key on a keyboard layout
+type Key struct {
+	// Label is the text printed on the key
+	Label string
+	// Name is the xkb keysym name of the key as used in izu configs
+	Name string
+	// Width is the width of the key in units, where a letter key is 1 unit wide
+	Width float64
+	// Modifier is the izu name of the modifier if this is a modifier key
+	Modifier string
+}
+
+// Layout is a keyboard layout as rows of keys
+type Layout [][]Key
+
+// row creates a row of keys that are 1 unit wide where the label and keysym are the same
+func row(names ...string) []Key {
+	keys := []Key{}
+	for _, name := range names {
+		keys = append(keys, Key{name, name, 1, ""})
+	}
+	return keys
+}
+
+// join joins rows of keys into a single row
+func join(rows ...[]Key) []Key {
+	keys := []Key{}
+	for _, row := range rows {
+		keys = append(keys, row...)
+	}
+	return keys
+}
+
+// functionRow is the row of escape and function keys, which is the same for every layout
+var functionRow = join([]Key{{"Esc", "Escape", 1, ""}}, row("F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12"))
+
+// numberRow is the row of digits, which is the same for every layout
+var numberRow = join([]Key{{"`", "grave", 1, ""}}, row("1", "2", "3", "4", "5", "6", "7", "8", "9", "0"), []Key{{"-", "minus", 1, ""}, {"=", "equal", 1, ""}, {"Bksp", "BackSpace", 2, ""}})
+
+// modifierRow is the bottom row of modifiers and the space bar, which is the same for every layout
+var modifierRow = []Key{
+	{"Ctrl", "Control_L", 1.25, "ctrl"},
+	{"Super", "Super_L", 1.25, "super"},
+	{"Alt", "Alt_L", 1.25, "alt"},
+	{"Space", "space", 6.25, ""},
+	{"Alt", "Alt_R", 1.25, "alt"},
+	{"Super", "Super_R", 1.25, "super"},
+	{"Menu", "Menu", 1.25, ""},
+	{"Ctrl", "Control_R", 1.25, "ctrl"},
+}
+
+// ANSI is the US keyboard layout
+var ANSI = Layout{
+	functionRow,
+	numberRow,
+	join([]Key{{"Tab", "Tab", 1.5, ""}}, row("q", "w", "e", "r", "t", "y", "u", "i", "o", "p"), []Key{{"[", "bracketleft", 1, ""}, {"]", "bracketright", 1, ""}, {"\\", "backslash", 1.5, ""}}),
+	join([]Key{{"Caps", "Caps_Lock", 1.75, ""}}, row("a", "s", "d", "f", "g", "h", "j", "k", "l"), []Key{{";", "semicolon", 1, ""}, {"'", "apostrophe", 1, ""}, {"Enter", "Return", 2.25, ""}}),
+	join([]Key{{"Shift", "Shift_L", 2.25, "shift"}}, row("z", "x", "c", "v", "b", "n", "m"), []Key{{",", "comma", 1, ""}, {".", "period", 1, ""}, {"/", "slash", 1, ""}, {"Shift", "Shift_R", 2.75, "shift"}}),
+	modifierRow,
+}
+
+// ISO is the european keyboard layout with the extra key next to the left shift and the tall enter key
+// the enter key is drawn as a key at the end of the second and third row
+var ISO = Layout{
+	functionRow,
+	numberRow,
+	join([]Key{{"Tab", "Tab", 1.5, ""}}, row("q", "w", "e", "r", "t", "y", "u", "i", "o", "p"), []Key{{"[", "bracketleft", 1, ""}, {"]", "bracketright", 1, ""}, {"Enter", "Return", 1.5, ""}}),
+	join([]Key{{"Caps", "Caps_Lock", 1.75, ""}}, row("a", "s", "d", "f", "g", "h", "j", "k", "l"), []Key{{";", "semicolon", 1, ""}, {"'", "apostrophe", 1, ""}, {"#", "numbersign", 1, ""}, {"Enter", "Return", 1.25, ""}}),
+	join([]Key{{"Shift", "Shift_L", 1.25, "shift"}, {"<", "less", 1, ""}}, row("z", "x", "c", "v", "b", "n", "m"), []Key{{",", "comma", 1, ""}, {".", "period", 1, ""}, {"/", "slash", 1, ""}, {"Shift", "Shift_R", 2.75, "shift"}}),
+	modifierRow,
+}
+
+// layouts are the layouts by name
+var layouts = map[string]Layout{
+	"ansi": ANSI,
+	"iso":  ISO,
+}
+
+// Get returns the layout with the given name
+func Get(name string) (Layout, error) {
+	layout, ok := layouts[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown keyboard layout '%s', expected one of %s", name, strings.Join(Names(), ", "))
+	}
+	return layout, nil
+}
+
+// Names returns the names of all the layouts, sorted by name
+func Names() []string {
+	names := []string{}
+	for name := range layouts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ASCII draws the layout as text, keys for which marked returns true are drawn as [*key*] instead of [ key ]
+func (layout Layout) ASCII(marked func(name string) bool) string {
+	lines := []string{}
+	for _, row := range layout {
+		line := ""
+		for _, key := range row {
+			if marked(key.Name) {
+				line += "[*" + key.Label + "*]"
+			} else {
+				line += "[ " + key.Label + " ]"
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,80 @@
+package keyboard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/internal/query"
+	"github.com/meir/izu/pkg/izu"
+)
+
+func TestASCII(t *testing.T) {
+	layout := Layout{
+		row("q", "w", "e"),
+		{{"Space", "space", 6.25, ""}},
+	}
+
+	expected := "[ q ][*w*][ e ]\n[*Space*]\n"
+	output := layout.ASCII(func(name string) bool {
+		return name == "w" || name == "space"
+	})
+	if output != expected {
+		t.Errorf("ascii layout is\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	input := `# open a terminal
+super + Return
+  foot
+
+super + {_,shift +} q
+  {close,kill}
+
+super + {a,b} | sway[mode=resize]
+  echo {a,b}`
+
+	hotkeys, err := parser.Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	formatter, err := luaformatter.NewFormatter("sway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bindings, _, err := formatter.Expand(hotkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the bindings in the resize mode are not part of the layers of the default mode
+	layers := query.Layers(query.Bindings(bindings, ""))
+	if len(layers) != 2 || layers[0].Name() != "super" || layers[1].Name() != "super+shift" {
+		t.Fatalf("returned unexpected layers %v", layers)
+	}
+
+	svg := string(ANSI.SVG("sway super", layers[0]))
+	for _, expected := range []string{
+		"<title>super+Return: open a terminal</title>",
+		"<title>super+q: close</title>",
+		`fill="` + heat[0] + `"`,
+	} {
+		if !strings.Contains(svg, expected) {
+			t.Errorf("svg does not contain '%s'", expected)
+		}
+	}
+	if strings.Contains(svg, "<title>super+a: echo a</title>") {
+		t.Errorf("svg contains a binding of the resize mode")
+	}
+
+	svg = string(ISO.SVG("sway super+shift", layers[1]))
+	if !strings.Contains(svg, "<title>super+shift+q: kill</title>") || !strings.Contains(svg, `fill="`+heldColor+`"`) {
+		t.Errorf("svg of the super+shift layer is missing the bound or held keys")
+	}
+}
@@ -0,0 +1,87 @@
+package keyboard
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/meir/izu/internal/query"
+)
+
+const (
+	// unit is the size of a 1 unit key in pixels
+	unit = 48.0
+	// gap is the space between keys in pixels
+	gap = 4.0
+	// header is the height of the title above the keyboard in pixels
+	header = 32.0
+)
+
+// heat are the colors of bound keys, by the amount of bindings on the key
+var heat = []string{"#fdd49e", "#fc8d59", "#d7301f"}
+
+const (
+	freeColor = "#eeeeee"
+	heldColor = "#9ecae1"
+)
+
+// SVG draws the layout as a standalone svg image for a layer of bindings
+// bound keys are colored by the amount of bindings on them, the modifiers of the layer are drawn as held
+// and hovering a key shows the key combination with the description of the hotkey or its command
+func (layout Layout) SVG(title string, layer query.Layer) []byte {
+	width := 0.0
+	for _, row := range layout {
+		rowWidth := 0.0
+		for _, key := range row {
+			rowWidth += key.Width * unit
+		}
+		width = max(width, rowWidth)
+	}
+	height := header + float64(len(layout))*unit
+
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g" font-family="sans-serif" font-size="12">`+"\n", width+gap, height+gap, width+gap, height+gap)
+	fmt.Fprintf(builder, `  <text x="%g" y="%g" font-size="16">%s</text>`+"\n", gap, header-12, html.EscapeString(title))
+
+	for y, row := range layout {
+		x := 0.0
+		for _, key := range row {
+			color, hover := freeColor, ""
+			name := query.Normalise([]string{key.Name})[0]
+			if bindings := layer.Keys[name]; len(bindings) > 0 {
+				color = heat[min(len(bindings), len(heat))-1]
+				hover = describe(append(append([]string{}, layer.Modifiers...), name), bindings)
+			} else if key.Modifier != "" && slices.Contains(layer.Modifiers, key.Modifier) {
+				color = heldColor
+			}
+
+			left, top, keyWidth := x+gap, header+float64(y)*unit+gap, key.Width*unit-gap
+			fmt.Fprintf(builder, `  <g>`+"\n")
+			if hover != "" {
+				fmt.Fprintf(builder, `    <title>%s</title>`+"\n", html.EscapeString(hover))
+			}
+			fmt.Fprintf(builder, `    <rect x="%g" y="%g" width="%g" height="%g" rx="4" fill="%s" stroke="#999999"/>`+"\n", left, top, keyWidth, unit-gap, color)
+			fmt.Fprintf(builder, `    <text x="%g" y="%g" text-anchor="middle" dominant-baseline="middle">%s</text>`+"\n", left+keyWidth/2, top+(unit-gap)/2, html.EscapeString(key.Label))
+			fmt.Fprintf(builder, `  </g>`+"\n")
+			x += key.Width * unit
+		}
+	}
+
+	builder.WriteString("</svg>\n")
+	return []byte(builder.String())
+}
+
+// describe returns a line for every binding with the key combination and the description of the hotkey,
+// or its command if the hotkey has no description
+func describe(combo []string, bindings []query.Binding) string {
+	lines := []string{}
+	for _, binding := range bindings {
+		description := binding.Hotkey.Description
+		if description == "" {
+			description = binding.Command
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", strings.Join(combo, "+"), description))
+	}
+	return strings.Join(lines, "\n")
+}
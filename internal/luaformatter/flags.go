@@ -0,0 +1,88 @@
+package luaformatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/meir/izu/pkg/izu"
+)
+
+// FlagError is a flag given to a hotkey that is unknown to the formatter or has a malformed value
+type FlagError struct {
+	Line    int
+	System  string
+	Flag    string
+	Message string
+}
+
+func (err FlagError) Error() string {
+	return fmt.Sprintf("line %d: %s (%s)", err.Line, err.Message, err.System)
+}
+
+// ValidateFlags checks the flags given to the hotkeys for the system of the formatter against the flags it declares,
+// for an alias the flags given under the name of the formatter are checked as well, formatters that do not declare
+// their flags accept any flag
+func (formatter *Formatter) ValidateFlags(hotkeys []*izu.Hotkey) []FlagError {
+	declared := formatter.metadata.Flags
+	if declared == nil {
+		return nil
+	}
+
+	errs := []FlagError{}
+	for _, hotkey := range hotkeys {
+		for _, system := range formatter.systems() {
+			for _, flag := range hotkey.Flags[system] {
+				if message := validateFlag(declared, flag); message != "" {
+					errs = append(errs, FlagError{hotkey.Line, system, flag.String(), message})
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// validateFlag returns why the flag does not match any of the declared flags, or an empty string if it does
+// flags with a value are written as name=value, the older name-value form is still accepted for bare flags
+func validateFlag(declared []Flag, flag izu.Flag) string {
+	for _, declaration := range declared {
+		if declaration.Name != flag.Name {
+			continue
+		}
+		switch {
+		case declaration.Type == "bool" && flag.HasValue():
+			return fmt.Sprintf("flag '%s' does not take a value", flag.Name)
+		case declaration.Type != "bool" && !flag.HasValue():
+			return fmt.Sprintf("flag '%s' expects a %s value, written as %s=<value>", flag.Name, declaration.Type, flag.Name)
+		}
+		return validateValue(declaration, flag.Value)
+	}
+
+	for _, declaration := range declared {
+		if declaration.Type == "bool" || flag.HasValue() {
+			continue
+		}
+		if value, ok := strings.CutPrefix(flag.Name, declaration.Name+"-"); ok {
+			return validateValue(declaration, value)
+		}
+	}
+
+	names := []string{}
+	for _, declaration := range declared {
+		names = append(names, declaration.Name)
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("unknown flag '%s', the formatter does not accept any flags", flag.Name)
+	}
+	return fmt.Sprintf("unknown flag '%s', expected one of %s", flag.Name, strings.Join(names, ", "))
+}
+
+// validateValue returns why the value does not match the type of the flag, or an empty string if it does
+func validateValue(declaration Flag, value string) string {
+	if declaration.Type == "number" {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Sprintf("flag '%s' expects a number value, got '%s'", declaration.Name, value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package luaformatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+)
+
+func TestValidateFlags(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases := []struct {
+		system string
+		flags  []string
+		errs   []string
+	}{
+		{"hyprland", []string{"l", "r", "submap=resize"}, []string{}},
+		{"hyprland", []string{"submap-resize"}, []string{}},
+		{"hyprland", []string{"l=1"}, []string{"flag 'l' does not take a value"}},
+		{"hyprland", []string{"k"}, []string{"unknown flag 'k', expected one of l, r, e, n, m, t, i, s, d, p, submap"}},
+		{"hyprland", []string{"submap"}, []string{"flag 'submap' expects a string value, written as submap=<value>"}},
+		{"niri", []string{"cooldown-ms-150", "no-repeat"}, []string{}},
+		{"niri", []string{"cooldown-ms=fast"}, []string{"flag 'cooldown-ms' expects a number value, got 'fast'"}},
+		{"niri", []string{"cooldown-ms-fast"}, []string{"flag 'cooldown-ms' expects a number value, got 'fast'"}},
+		{"sxhkd", []string{"release"}, []string{"unknown flag 'release', the formatter does not accept any flags"}},
+	}
+
+	for case_index, c := range cases {
+		formatter, err := NewFormatter(c.system)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		flags := []izu.Flag{}
+		for _, flag := range c.flags {
+			name, value, _ := strings.Cut(flag, "=")
+			flags = append(flags, izu.Flag{Name: name, Value: value})
+		}
+
+		hotkey := &izu.Hotkey{Line: 3, Flags: map[string][]izu.Flag{c.system: flags}}
+		errs := []string{}
+		for _, err := range formatter.ValidateFlags([]*izu.Hotkey{hotkey}) {
+			if err.Line != 3 || err.System != c.system {
+				t.Errorf("#%d: error has line %d and system '%s'", case_index, err.Line, err.System)
+			}
+			errs = append(errs, err.Message)
+		}
+		if diff := deep.Equal(errs, c.errs); diff != nil {
+			t.Errorf("#%d: %v", case_index, diff)
+		}
+	}
+}
+
+func TestFormatFlags(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases := []struct {
+		system string
+		input  string
+		output []string
+	}{
+		{
+			"sway",
+			"super + a | sway[release mode=\"resize mode\" input-device=1:1:kb]\n  foot",
+			[]string{`mode "resize mode" bindsym --release --input-device=1:1:kb super+a exec foot`},
+		},
+		{"sway", "super + a | sway[mode-resize]\n  foot", []string{`mode "resize" bindsym super+a exec foot`}},
+		{"hyprland", "super + a | hyprland[l submap=launcher]\n  foot", []string{"submap = launcher", "bindl = Super, a, exec, foot", "submap = reset"}},
+		{"niri", "super + a | niri[cooldown-ms=150 hotkey-overlay-title=\"Open foot\"]\n  foot", []string{`Super+A cooldown-ms=150 hotkey-overlay-title="Open foot" { spawn "sh" "-c" "foot"; }`}},
+	}
+
+	for case_index, c := range cases {
+		hotkeys, err := parser.Parse([]byte(c.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		formatter, err := NewFormatter(c.system)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output, err := formatter.Format(hotkeys)
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", case_index, err)
+			continue
+		}
+		if diff := deep.Equal(output, c.output); diff != nil {
+			t.Errorf("#%d: %v", case_index, diff)
+		}
+	}
+}
+
+func TestFormatUnknownFlags(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	hotkeys, err := parser.Parse([]byte("super + a | sway[k] hyprland[k]\n  foot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		strict bool
+		output []string
+		err    string
+	}{
+		{false, []string{"bindsym super+a exec foot"}, ""},
+		{true, nil, "line 1: unknown flag 'k'"},
+	}
+
+	for i, c := range cases {
+		formatter, err := NewFormatterWithConfig("sway", Config{Strict: c.strict})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output, err := formatter.Format(hotkeys)
+		switch {
+		case c.err == "" && err != nil:
+			t.Errorf("#%d: returned error: %v", i, err)
+		case c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)):
+			t.Errorf("#%d: returned error '%v', want '%s'", i, err, c.err)
+		}
+		if diff := deep.Equal(output, c.output); diff != nil {
+			t.Errorf("#%d: %v", i, diff)
+		}
+	}
+}
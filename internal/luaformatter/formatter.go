@@ -1,31 +1,44 @@
 package luaformatter
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/meir/izu/pkg/izu"
 	lua "github.com/yuin/gopher-lua"
 )
 
+// documentHooks are the optional methods that are called with every formatted hotkey,
+// prelude and epilogue are placed before and after the hotkeys and document replaces the hotkeys
+var documentHooks = []string{"prelude", "document", "epilogue"}
+
 // Formatter is a lua based formatter to generate hotkey configurations
 type Formatter struct {
-	system  string
-	state   *lua.LState
-	methods map[string]lua.LValue
+	system   string
+	name     string
+	state    *lua.LState
+	methods  map[string]lua.LValue
+	hooks    map[string]lua.LValue
+	reload   string
+	dispatch lua.LValue
+	timeout  time.Duration
+	strict   bool
+	metadata Metadata
 }
 
-// NewFormatter creates a new lua formatter for the given system
+// NewFormatter creates a new sandboxed lua formatter for the given system
 func NewFormatter(system string) (*Formatter, error) {
-	// initialize helper methods
-	slog.Debug("Initializing lua formatter", "system", system)
-	state := lua.NewState()
-	table := state.NewTable()
-	table.RawSetString("lowercase", state.NewFunction(lowercase))
-	table.RawSetString("uppercase", state.NewFunction(uppercase))
-	table.RawSetString("contains", state.NewFunction(contains))
+	return NewFormatterWithConfig(system, Config{})
+}
 
-	state.SetGlobal("izu", table)
+// NewFormatterWithConfig creates a new lua formatter for the given system, the config decides how the lua is run
+func NewFormatterWithConfig(system string, config Config) (*Formatter, error) {
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
 
 	if system == "" {
 		return nil, fmt.Errorf("formatter/system cannot be empty")
@@ -33,14 +46,71 @@ func NewFormatter(system string) (*Formatter, error) {
 
 	// load in lua formatter file
 	slog.Debug("Loading lua formatter file", "system", system)
+	name := system
 	content, err := izu.GetFormatterFile("lua", system)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load lua formatter file for %s: %w", system, err)
+		// the system might be an alias of one of the embedded formatters, such as i3 for sway
+		alias, ok := resolveAlias(system)
+		if !ok {
+			return nil, fmt.Errorf("failed to load lua formatter file for %s: %w", system, err)
+		}
+		slog.Debug("Using formatter for alias", "system", system, "formatter", alias)
+		name = alias
+		content, err = izu.GetFormatterFile("lua", name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load lua formatter file for %s: %w", system, err)
+		}
+	}
+
+	// modules are required from next to the formatter file and from the embedded formatters
+	path, _ := izu.GetFormatterPath("lua", name)
+	formatter, err := loadFormatter(system, content, path, config)
+	if err != nil {
+		return nil, err
+	}
+	formatter.name = name
+	return formatter, nil
+}
+
+// loadFormatter runs the content of a formatter file for the system, modules are required from next to the path
+func loadFormatter(system string, content []byte, path string, config Config) (*Formatter, error) {
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
 	}
 
+	// initialize helper methods
+	slog.Debug("Initializing lua formatter", "system", system, "unsafe", config.Unsafe)
+	state := newState(config)
+	table := state.NewTable()
+	table.RawSetString("lowercase", state.NewFunction(lowercase))
+	table.RawSetString("uppercase", state.NewFunction(uppercase))
+	table.RawSetString("contains", state.NewFunction(contains))
+	table.RawSetString("split", state.NewFunction(split))
+	table.RawSetString("join", state.NewFunction(join))
+	table.RawSetString("trim", state.NewFunction(trim))
+	table.RawSetString("replace", state.NewFunction(replace))
+	table.RawSetString("modifier", state.NewFunction(modifier))
+	table.RawSetString("is_modifier", state.NewFunction(isModifier))
+	table.RawSetString("split_modifiers", state.NewFunction(splitModifiers))
+	table.RawSetString("order_modifiers", state.NewFunction(orderModifiers))
+	table.RawSetString("map_keys", state.NewFunction(mapKeys))
+	table.RawSetString("shell_quote", state.NewFunction(shellQuote))
+	table.RawSetString("keysym", state.NewFunction(keysym))
+	table.RawSetString("options", readOnlyTable(state, "izu.options", config.Options))
+
+	state.SetGlobal("izu", table)
+	state.SetGlobal("require", newRequire(state, path, state.GetGlobal("require")))
+
 	// run the lua file in order to retrieve the AST methods
 	slog.Debug("Running lua formatter file", "system", system)
-	if err := state.DoString(string(content)); err != nil {
+	formatter := &Formatter{
+		system:  system,
+		name:    system,
+		state:   state,
+		timeout: config.Timeout,
+		strict:  config.Strict,
+	}
+	if err := formatter.run(func() error { return state.DoString(string(content)) }); err != nil {
 		return nil, err
 	}
 
@@ -49,6 +119,10 @@ func NewFormatter(system string) (*Formatter, error) {
 
 	// check if the response is an object
 	methods := map[string]lua.LValue{}
+	hooks := map[string]lua.LValue{}
+	reload := ""
+	var dispatch lua.LValue
+	metadata := Metadata{}
 	if module, ok := module.(*lua.LTable); ok {
 		// add all the AST names in a list, these will be used as the required method names
 		asts := []string{
@@ -69,15 +143,115 @@ func NewFormatter(system string) (*Formatter, error) {
 				return nil, fmt.Errorf("expected a function '%s' to be returned within the lua formatter module", method)
 			}
 		}
+
+		// the document methods are optional and get the full list of formatted hotkeys
+		for _, hook := range documentHooks {
+			switch function := module.RawGetString(hook); function.Type() {
+			case lua.LTFunction:
+				slog.Debug("Found document method in lua formatter module", "method", hook)
+				hooks[hook] = function
+			case lua.LTNil:
+			default:
+				return nil, fmt.Errorf("expected '%s' to be a function in the lua formatter module, got '%s'", hook, function.Type().String())
+			}
+		}
+
+		var err error
+		metadata, err = readMetadata(module)
+		if err != nil {
+			return nil, err
+		}
+		if metadata.Name == "" {
+			metadata.Name = system
+		}
+
+		// the optional reload field is the command that makes the hotkey daemon read its config again
+		reload, err = optionalString(module, "reload")
+		if err != nil {
+			return nil, err
+		}
+
+		// the optional dispatch method returns the command line that runs a command of this system from a shell
+		switch function := module.RawGetString("dispatch"); function.Type() {
+		case lua.LTFunction:
+			slog.Debug("Found dispatch method in lua formatter module")
+			dispatch = function
+		case lua.LTNil:
+		default:
+			return nil, fmt.Errorf("expected 'dispatch' to be a function in the lua formatter module, got '%s'", function.Type().String())
+		}
 	} else {
 		return nil, fmt.Errorf("expected a table to be returned in the lua formatter file")
 	}
 
-	return &Formatter{
-		system:  system,
-		state:   state,
-		methods: methods,
-	}, nil
+	formatter.methods = methods
+	formatter.hooks = hooks
+	formatter.metadata = metadata
+	formatter.reload = reload
+	formatter.dispatch = dispatch
+	return formatter, nil
+}
+
+// optionalString returns the string field of the formatter module, or an empty string if it is not set
+func optionalString(module *lua.LTable, name string) (string, error) {
+	switch value := module.RawGetString(name); value.Type() {
+	case lua.LTString:
+		return value.String(), nil
+	case lua.LTNil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("expected '%s' to be a string in the lua formatter module, got '%s'", name, value.Type().String())
+	}
+}
+
+// System returns the name of the system this formatter formats for
+func (formatter *Formatter) System() string {
+	return formatter.system
+}
+
+// Name returns the name of the formatter file, this is the system unless the system is an alias such as i3 for sway
+func (formatter *Formatter) Name() string {
+	return formatter.name
+}
+
+// systems returns the systems the commands and flags of a hotkey are looked up under, the system itself first
+// and the name of the formatter after it when the system is an alias
+func (formatter *Formatter) systems() []string {
+	if formatter.name == formatter.system {
+		return []string{formatter.system}
+	}
+	return []string{formatter.system, formatter.name}
+}
+
+// Reload returns the command to reload the hotkey daemon as given by the formatter, this can be empty
+func (formatter *Formatter) Reload() string {
+	return formatter.reload
+}
+
+// Metadata returns the name, description, version, flags and aliases declared by the formatter
+func (formatter *Formatter) Metadata() Metadata {
+	return formatter.metadata
+}
+
+// Dispatch returns the command line that runs the command of the binding, such as swaymsg with the command for sway
+// formatters without a dispatch method have shell commands, which are run using sh -c
+func (formatter *Formatter) Dispatch(binding Binding) ([]string, error) {
+	if formatter.dispatch == nil {
+		return []string{"sh", "-c", binding.Command}, nil
+	}
+
+	args, err := formatter.call("dispatch", formatter.dispatch,
+		OptionString(binding.Command),
+		OptionDefault(binding.Default),
+		OptionFlags(binding.Flags),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("formatter method 'dispatch' returned no command for '%s'", binding.Command)
+	}
+	return args, nil
 }
 
 // Call will run the lua method for the given AST type using the options given
@@ -87,7 +261,11 @@ func (formatter *Formatter) Call(method izu.AST, options ...Option) ([]string, e
 	if !ok {
 		return nil, fmt.Errorf("cannot find method for %s", method.String())
 	}
+	return formatter.call(method.String(), function, options...)
+}
 
+// call will run the lua function with a table of the options and return the lines it returns
+func (formatter *Formatter) call(name string, function lua.LValue, options ...Option) ([]string, error) {
 	// add all the options in a table using the key-value
 	// later ones will override the key, this just means that its higher in the tree
 	value := &lua.LTable{}
@@ -96,16 +274,19 @@ func (formatter *Formatter) Call(method izu.AST, options ...Option) ([]string, e
 	}
 
 	// call the lua method
-	err := formatter.state.CallByParam(lua.P{
-		Fn:      function,
-		NRet:    1,
-		Protect: true,
-	}, value)
+	err := formatter.run(func() error {
+		return formatter.state.CallByParam(lua.P{
+			Fn:      function,
+			NRet:    1,
+			Protect: true,
+		}, value)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to call lua formatting method %s: %w", method.String(), err)
+		return nil, fmt.Errorf("failed to call lua formatting method %s: %w", name, err)
 	}
 
 	response := formatter.state.Get(-1)
+	formatter.state.Pop(1)
 
 	// check if the response is either a string or a string array
 	// if its anything else, return an error
@@ -123,51 +304,112 @@ func (formatter *Formatter) Call(method izu.AST, options ...Option) ([]string, e
 			}
 
 			if value.Type() != lua.LTString {
-				err = fmt.Errorf("expected only strings to be returned in an array from formatter method '%s', received a '%s'", method.String(), value.Type().String())
+				err = fmt.Errorf("expected only strings to be returned in an array from formatter method '%s', received a '%s'", name, value.Type().String())
 			}
 
 			output = append(output, value.String())
 		})
 
-		return output, nil
+		return output, err
 	default:
-		return nil, fmt.Errorf("expected a string or string array to be returned from formatter method '%s' instead got '%s'", method.String(), response.Type().String())
+		return nil, fmt.Errorf("expected a string or string array to be returned from formatter method '%s' instead got '%s'", name, response.Type().String())
 	}
 }
 
-// Format will take a list of hotkeys and format them into strings that can be used in the config file of the hotkey system
-func (formatter *Formatter) Format(hotkeys []*izu.Hotkey) ([]string, error) {
+// Binding is a single binding of a hotkey after its multiples are expanded and formatted for the system
+type Binding struct {
+	Hotkey  *izu.Hotkey
+	Binding string
+	Command string
+	// Default is true when the hotkey has no command for this system and the default command is used
+	Default bool
+	// Flags are the flags of the hotkey for this system
+	Flags []izu.Flag
+	// Mode is the mode of the hotkey daemon the binding is in, using the mode flag of the formatter
+	// this is empty for bindings that are not in a mode
+	Mode string
+	// Combos are the key combinations that fire the binding, before they are formatted
+	// formatters that keep the multiples in the binding, such as sxhkd, have a single binding for every combination
+	Combos []Combo
+	// Lines is the output of the hotkey method of the formatter
+	Lines []string
+}
+
+// Combo is a single key combination of a binding with the command it fires
+type Combo struct {
+	Keys []string
+	// Command is the command of the combination, its multiples are expanded even when the formatter keeps them
+	Command string
+}
+
+// Expand will take a list of hotkeys and format every binding of them, including every path of their multiples
+// hotkeys that have no command for this system and no default command are returned as skipped
+func (formatter *Formatter) Expand(hotkeys []*izu.Hotkey) ([]Binding, []*izu.Hotkey, error) {
 	slog.Debug("Formatting hotkeys", "system", formatter.system)
-	output := []string{}
+	output := []Binding{}
+	skipped := []*izu.Hotkey{}
 	for _, hotkey := range hotkeys {
 		slog.Debug("Formatting hotkey", "hotkey", hotkey.String())
-		flags := []string{}
+		flags := []izu.Flag{}
 		// check if there are any flags assigned for this system
-		if sflags, ok := hotkey.Flags[formatter.system]; ok {
-			flags = sflags
+		for _, system := range formatter.systems() {
+			if sflags, ok := hotkey.Flags[system]; ok {
+				flags = sflags
+				break
+			}
 		}
 
 		// format the binding of this hotkey
-		bindings, err := formatter.format(hotkey.Binding, OptionFlags(flags), OptionStateBinding())
+		bindings, err := formatter.format(hotkey.Binding, formatter.Call, OptionFlags(flags), OptionStateBinding())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// check if theres a specific command for this system, otherwise use the default
-		// if theres no default and this system is not specified, return an error
+		// if theres no default and this system is not specified, skip the hotkey
 		var command izu.Part
-		if scommand, ok := hotkey.Command[formatter.system]; ok {
-			command = scommand
-		} else if scommand, ok := hotkey.Command["default"]; ok {
-			command = scommand
-		} else {
-			slog.Warn("No command found for hotkey", "hotkey", hotkey.String(), "system", formatter.system)
-			continue
+		isDefault := false
+		for _, system := range formatter.systems() {
+			if scommand, ok := hotkey.Command[system]; ok {
+				command = scommand
+				break
+			}
+		}
+		if command == nil {
+			if scommand, ok := hotkey.Command["default"]; ok {
+				command = scommand
+				isDefault = true
+			} else {
+				skipped = append(skipped, hotkey)
+				continue
+			}
 		}
 
 		// format the command part of this hotkey
-		commands, err := formatter.format(command, OptionFlags(flags), OptionStateCommand())
+		commands, err := formatter.format(command, formatter.Call, OptionFlags(flags), OptionStateCommand())
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(commands) == 0 {
+			skipped = append(skipped, hotkey)
+			continue
+		}
+
+		// the keys and the command are expanded in the same way as the binding, without the formatter
+		combos, err := formatter.format(hotkey.Binding, callKeys, OptionStateBinding())
+		if err != nil {
+			return nil, nil, err
+		}
+		expanded, err := formatter.format(command, callCommand, OptionStateCommand())
+		if err != nil {
+			return nil, nil, err
+		}
+		// the commands of the formatter are used when it expands the multiples itself
+		if len(expanded) <= len(commands) {
+			expanded = commands
+		}
 
+		first := len(output)
 		for i, binding := range bindings {
 			// each hotkey might turn into several bindings and several commands (due to multiples)
 			// if this is the case, we need to find the command thats part of the current binding
@@ -182,28 +424,171 @@ func (formatter *Formatter) Format(hotkeys []*izu.Hotkey) ([]string, error) {
 				OptionStateHotkey(),
 				OptionAST(izu.ASTHotkey),
 				OptionFlags(flags),
+				OptionDefault(isDefault),
 			)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
-			// and add it to the output
-			output = append(output, response...)
+			output = append(output, Binding{
+				Hotkey:  hotkey,
+				Binding: binding,
+				Command: command,
+				Default: isDefault,
+				Flags:   flags,
+				Mode:    formatter.mode(flags),
+				Combos:  []Combo{},
+				Lines:   response,
+			})
 			slog.Debug("Formatted hotkey", "binding", binding, "command", command)
 		}
+
+		// the combinations are paired with the bindings and the commands like the commands are paired with the bindings
+		if len(bindings) == 0 || len(expanded) == 0 {
+			continue
+		}
+		for i, combo := range combos {
+			binding := &output[first+i%len(bindings)]
+			binding.Combos = append(binding.Combos, Combo{splitKeys(combo), expanded[i%len(expanded)]})
+		}
+	}
+	return output, skipped, nil
+}
+
+// mode returns the mode the flags bind the hotkey in, using the mode flag declared by the formatter
+func (formatter *Formatter) mode(flags []izu.Flag) string {
+	name := formatter.metadata.Mode
+	if name == "" {
+		return ""
+	}
+	for _, flag := range flags {
+		if flag.Name == name && flag.HasValue() {
+			return flag.Value
+		}
+		// the mode used to be written as name-value, which is still accepted
+		if value, ok := strings.CutPrefix(flag.Name, name+"-"); ok && !flag.HasValue() {
+			return value
+		}
+	}
+	return ""
+}
+
+// Format will take a list of hotkeys and format them into strings that can be used in the config file of the hotkey system
+func (formatter *Formatter) Format(hotkeys []*izu.Hotkey) ([]string, error) {
+	// flags the formatter does not declare are reported before any lua runs, they only stop formatting when strict
+	if errs := formatter.ValidateFlags(hotkeys); len(errs) > 0 {
+		if formatter.strict {
+			joined := []error{}
+			for _, err := range errs {
+				joined = append(joined, err)
+			}
+			return nil, errors.Join(joined...)
+		}
+		for _, err := range errs {
+			slog.Warn("Unknown flag for hotkey", "line", err.Line, "flag", err.Flag, "system", err.System, "error", err.Message)
+		}
+	}
+
+	bindings, skipped, err := formatter.Expand(hotkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hotkey := range skipped {
+		slog.Warn("No command found for hotkey", "hotkey", hotkey.String(), "system", formatter.system)
+	}
+
+	return formatter.document(bindings)
+}
+
+// document will put the lines of all the bindings together, using the document methods of the formatter if it has them
+func (formatter *Formatter) document(bindings []Binding) ([]string, error) {
+	output := []string{}
+	for _, hook := range documentHooks {
+		function, ok := formatter.hooks[hook]
+		if !ok {
+			// without a document method the lines of every binding are used as is
+			if hook == "document" {
+				for _, binding := range bindings {
+					output = append(output, binding.Lines...)
+				}
+			}
+			continue
+		}
+
+		lines, err := formatter.call(hook, function, OptionHotkeys(bindings), OptionSystem(formatter.system))
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, lines...)
 	}
 	return output, nil
 }
 
+// caller formats a part of a hotkey, this is Call for the lua methods or callKeys for the keys of a binding
+type caller func(method izu.AST, options ...Option) ([]string, error)
+
+// keySeparator joins the keys of a combination in callKeys, a + is never part of a key as it separates the keys in the config
+const keySeparator = "+"
+
+// callKeys formats the parts of a binding like a formatter without lua would, the keys are joined by keySeparator
+func callKeys(method izu.AST, options ...Option) ([]string, error) {
+	values := []string{}
+	state := OptionStateHotkey().value
+	for _, option := range options {
+		switch option.name {
+		case "value":
+			if table, ok := option.value.(*lua.LTable); ok {
+				values = tableStrings(table)
+			} else {
+				values = []string{option.value.String()}
+			}
+		case "state":
+			state = option.value
+		}
+	}
+
+	switch method {
+	case izu.ASTSingle:
+		// the _ of {_,shift} is no key
+		if key := strings.Join(values, ""); key != "_" {
+			return []string{key}, nil
+		}
+		return []string{}, nil
+	case izu.ASTBinding:
+		if state == OptionStateBinding().value || state == OptionStateMultiBinding().value {
+			return []string{strings.Join(values, keySeparator)}, nil
+		}
+		return []string{strings.Join(values, "")}, nil
+	}
+	return values, nil
+}
+
+// callCommand formats the parts of a command like callKeys, the parts of the command are joined as they are
+func callCommand(method izu.AST, options ...Option) ([]string, error) {
+	return callKeys(method, append(options, OptionStateCommand())...)
+}
+
+// splitKeys splits a combination joined by callKeys into its keys
+func splitKeys(combo string) []string {
+	keys := []string{}
+	for _, key := range strings.Split(combo, keySeparator) {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 // format will take a part and format it into one or multiple bindings/commands and call the lua methods in order to properly format it
-func (formatter *Formatter) format(root izu.Part, opts ...Option) (output []string, err error) {
+func (formatter *Formatter) format(root izu.Part, call caller, opts ...Option) (output []string, err error) {
 	kind, partlist := root.Info()
 
 	// if the part is a string, call the lua method and return its output, we dont need any other processing on this part
 	if kind == izu.ASTString {
 		opts = append(opts, OptionString(root.String()))
 		opts = append(opts, OptionAST(kind))
-		output, err = formatter.Call(izu.ASTString, opts...)
+		output, err = call(izu.ASTString, opts...)
 		return
 	}
 
@@ -220,7 +605,7 @@ func (formatter *Formatter) format(root izu.Part, opts ...Option) (output []stri
 			opts = append(opts, OptionStateMultiBinding())
 		}
 
-		bindings, err := formatter.format(part, opts...)
+		bindings, err := formatter.format(part, call, opts...)
 		if err != nil {
 			return err
 		}
@@ -258,7 +643,7 @@ func (formatter *Formatter) format(root izu.Part, opts ...Option) (output []stri
 	// call the lua method using the inputs and return the output
 	for _, input := range inputs {
 		opts = append(opts, OptionStringArray(input))
-		response, err := formatter.Call(kind, opts...)
+		response, err := call(kind, opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,251 @@
+package luaformatter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+)
+
+func TestDocument(t *testing.T) {
+	input := `# terminal
+super + t
+  foot
+
+super + {b,a}
+  echo {b,a}`
+
+	cases := []struct {
+		code   string
+		output []string
+	}{
+		{``, []string{"super+t foot", "super+b echo b", "super+a echo a"}},
+		{
+			`function formatter.prelude (args) return "binds " .. type(args.system) .. " {" end
+			 function formatter.epilogue (args) return {"}", "# " .. #args.hotkeys .. " hotkeys"} end`,
+			[]string{"binds string {", "super+t foot", "super+b echo b", "super+a echo a", "}", "# 3 hotkeys"},
+		},
+		{
+			`function formatter.document (args)
+			   local output = {}
+			   table.sort(args.hotkeys, function (a, b) return a.binding < b.binding end)
+			   for _, hotkey in ipairs(args.hotkeys) do
+			     local line = hotkey.lines[1] .. " (line " .. hotkey.line .. ")"
+			     if hotkey.description ~= "" then line = line .. " " .. hotkey.description end
+			     if #hotkey.flags > 0 then line = line .. " " .. table.concat(hotkey.flags, " ") end
+			     table.insert(output, line)
+			   end
+			   return output
+			 end`,
+			[]string{"super+a echo a (line 5) locked", "super+b echo b (line 5) locked", "super+t foot (line 2) terminal"},
+		},
+	}
+
+	hotkeys, err := parser.Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for case_index, c := range cases {
+		code := `local formatter = {}
+function formatter.hotkey (args) return table.concat(args.value, " ") end
+function formatter.binding (args)
+  if args.state == 1 then return table.concat(args.value, "+") end
+  return table.concat(args.value, "")
+end
+function formatter.multiple (args) return args.value end
+function formatter.single (args) return table.concat(args.value, "") end
+function formatter.string (args) return args.value end
+` + c.code + `
+return formatter`
+
+		path := filepath.Join(t.TempDir(), "test")
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		// the system of a formatter file is its path, which cannot be written as a flag in the config
+		hotkeys[1].Flags = map[string][]izu.Flag{path: {{Name: "locked"}}}
+
+		formatter, err := NewFormatter(path)
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", case_index, err)
+			continue
+		}
+
+		output, err := formatter.Format(hotkeys)
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", case_index, err)
+			continue
+		}
+		if diff := deep.Equal(output, c.output); diff != nil {
+			t.Errorf("#%d: %v", case_index, diff)
+		}
+	}
+}
+
+func TestDefaultCommands(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases := []struct {
+		system  string
+		options map[string]string
+		output  string
+	}{
+		{"sway", nil, "bindsym super+a exec foot"},
+		{"sway", map[string]string{"exec": "exec --no-startup-id"}, "bindsym super+a exec --no-startup-id foot"},
+		{"sway", map[string]string{"shell": "false"}, "bindsym super+a foot"},
+		{"hyprland", nil, "bind = Super, a, exec, foot"},
+		{"hyprland", map[string]string{"shell": "false"}, "bind = Super, a, foot"},
+		{"niri", nil, `Super+A { spawn "sh" "-c" "foot"; }`},
+		{"niri", map[string]string{"shell": "false"}, "Super+A { foot }"},
+	}
+
+	hotkeys, err := parser.Parse([]byte("super + a\n  foot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, c := range cases {
+		formatter, err := NewFormatterWithConfig(c.system, Config{Options: c.options})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output, err := formatter.Format(hotkeys)
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", i, err)
+			continue
+		}
+		if diff := deep.Equal(output, []string{c.output}); diff != nil {
+			t.Errorf("#%d: %v", i, diff)
+		}
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cases := []struct {
+		system  string
+		options map[string]string
+		input   string
+		args    []string
+	}{
+		{"sway", nil, "super + a\n  sway | focus left", []string{"swaymsg", "focus left"}},
+		{"sway", nil, "super + a\n  foot -e 'htop'", []string{"sh", "-c", "foot -e 'htop'"}},
+		{"sway", map[string]string{"shell": "false"}, "super + a\n  focus left", []string{"swaymsg", "focus left"}},
+		{"hyprland", nil, "super + a\n  hyprland | workspace, 1", []string{"hyprctl", "dispatch", "workspace", "1"}},
+		{"hyprland", nil, "super + a\n  hyprland | killactive,", []string{"hyprctl", "dispatch", "killactive"}},
+		{"hyprland", nil, "super + a\n  hyprland | exec, foot -e htop", []string{"hyprctl", "dispatch", "exec", "foot -e htop"}},
+		{"niri", nil, "super + a\n  niri | focus-workspace 3;", []string{"niri", "msg", "action", "focus-workspace", "3"}},
+		{"niri", nil, "super + a\n  niri | spawn \"foot\" \"-e\" \"echo \\\"hi\\\"\";", []string{"foot", "-e", `echo "hi"`}},
+		{"niri", nil, "super + a\n  niri | spawn-sh \"grim - | wl-copy\";", []string{"sh", "-c", "grim - | wl-copy"}},
+		{"sxhkd", nil, "super + a\n  bspc node -f west", []string{"sh", "-c", "bspc node -f west"}},
+	}
+
+	for i, c := range cases {
+		hotkeys, err := parser.Parse([]byte(c.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		formatter, err := NewFormatterWithConfig(c.system, Config{Options: c.options})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bindings, _, err := formatter.Expand(hotkeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		args, err := formatter.Dispatch(bindings[0])
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", i, err)
+			continue
+		}
+		if diff := deep.Equal(args, c.args); diff != nil {
+			t.Errorf("#%d: %v", i, diff)
+		}
+	}
+}
+
+func TestCombos(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	hotkeys, err := parser.Parse([]byte("super + {_,shift +} {a,b}\n  echo {a,b,c,d}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	combos := []Combo{
+		{[]string{"super", "a"}, "echo a"},
+		{[]string{"super", "shift", "a"}, "echo b"},
+		{[]string{"super", "b"}, "echo c"},
+		{[]string{"super", "shift", "b"}, "echo d"},
+	}
+
+	cases := []struct {
+		system string
+		combos [][]Combo
+	}{
+		// sxhkd keeps the multiples, so every combination is part of the same binding
+		{"sxhkd", [][]Combo{combos}},
+		{"sway", [][]Combo{combos[:1], combos[1:2], combos[2:3], combos[3:]}},
+	}
+
+	for i, c := range cases {
+		formatter, err := NewFormatter(c.system)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bindings, _, err := formatter.Expand(hotkeys)
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", i, err)
+			continue
+		}
+		output := [][]Combo{}
+		for _, binding := range bindings {
+			output = append(output, binding.Combos)
+		}
+		if diff := deep.Equal(output, c.combos); diff != nil {
+			t.Errorf("#%d: %v", i, diff)
+		}
+	}
+
+	// a binding that is formatted to nothing has no combinations
+	code := `local formatter = {}
+function formatter.hotkey (args) return args.value end
+function formatter.binding (args) return {} end
+function formatter.multiple (args) return args.value end
+function formatter.single (args) return args.value end
+function formatter.string (args) return args.value end
+return formatter`
+
+	path := filepath.Join(t.TempDir(), "empty.lua")
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	formatter, err := NewFormatter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bindings, _, err := formatter.Expand(hotkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bindings) != 0 {
+		t.Errorf("expected no bindings, got %+v", bindings)
+	}
+}
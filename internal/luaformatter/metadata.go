@@ -0,0 +1,149 @@
+package luaformatter
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/meir/izu/pkg/izu"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// FlagTypes are the types a flag declared by a formatter can have
+var FlagTypes = []string{"bool", "string", "number"}
+
+// Flag is a flag that is understood by a formatter
+type Flag struct {
+	Name string
+	// Type is one of FlagTypes, bool flags are written as the name of the flag
+	Type        string
+	Description string
+}
+
+// Metadata is the information a formatter module gives about itself, every field is optional
+type Metadata struct {
+	Name        string
+	Description string
+	Version     string
+	// Flags are the flags the formatter accepts, when this is nil the formatter did not declare its flags and any flag is accepted
+	Flags []Flag
+	// Aliases are other names of the system, such as i3 for sway, these can be used to select the formatter
+	Aliases []string
+	// Mode is the flag that binds a hotkey in another mode of the hotkey daemon, such as submap for hyprland
+	Mode string
+}
+
+// readMetadata reads the metadata fields of the formatter module
+func readMetadata(module *lua.LTable) (Metadata, error) {
+	metadata := Metadata{
+		Aliases: []string{},
+	}
+
+	for name, field := range map[string]*string{
+		"name":        &metadata.Name,
+		"description": &metadata.Description,
+		"version":     &metadata.Version,
+		"mode":        &metadata.Mode,
+	} {
+		value, err := optionalString(module, name)
+		if err != nil {
+			return metadata, err
+		}
+		*field = value
+	}
+
+	switch aliases := module.RawGetString("aliases"); aliases.Type() {
+	case lua.LTTable:
+		for _, alias := range tableStrings(aliases.(*lua.LTable)) {
+			metadata.Aliases = append(metadata.Aliases, alias)
+		}
+	case lua.LTNil:
+	default:
+		return metadata, fmt.Errorf("expected 'aliases' to be a list of strings in the lua formatter module, got '%s'", aliases.Type().String())
+	}
+
+	switch flags := module.RawGetString("flags"); flags.Type() {
+	case lua.LTTable:
+		var err error
+		metadata.Flags = []Flag{}
+		flags.(*lua.LTable).ForEach(func(_, value lua.LValue) {
+			if err != nil {
+				return
+			}
+
+			table, ok := value.(*lua.LTable)
+			if !ok {
+				err = fmt.Errorf("expected every flag in 'flags' to be a table in the lua formatter module, got '%s'", value.Type().String())
+				return
+			}
+
+			flag := Flag{
+				Name:        lua.LVAsString(table.RawGetString("name")),
+				Type:        lua.LVAsString(table.RawGetString("type")),
+				Description: lua.LVAsString(table.RawGetString("description")),
+			}
+			if flag.Type == "" {
+				flag.Type = "bool"
+			}
+
+			switch {
+			case flag.Name == "":
+				err = fmt.Errorf("expected every flag in 'flags' to have a name in the lua formatter module")
+			case !slices.Contains(FlagTypes, flag.Type):
+				err = fmt.Errorf("flag '%s' has the unknown type '%s', expected one of %v", flag.Name, flag.Type, FlagTypes)
+			}
+			metadata.Flags = append(metadata.Flags, flag)
+		})
+		if err != nil {
+			return metadata, err
+		}
+	case lua.LTNil:
+	default:
+		return metadata, fmt.Errorf("expected 'flags' to be a list of tables in the lua formatter module, got '%s'", flags.Type().String())
+	}
+
+	if metadata.Mode != "" && metadata.Flags != nil && !slices.ContainsFunc(metadata.Flags, func(flag Flag) bool {
+		return flag.Name == metadata.Mode && flag.Type == "string"
+	}) {
+		return metadata, fmt.Errorf("expected the mode '%s' to be one of the string flags in the lua formatter module", metadata.Mode)
+	}
+
+	return metadata, nil
+}
+
+var (
+	formatterAliasesOnce sync.Once
+	// formatterAliases maps the aliases declared by the embedded formatters to the name of the formatter
+	formatterAliases map[string]string
+)
+
+// resolveAlias returns the name of the embedded formatter that declares the system as one of its aliases,
+// the aliases are read from the embedded formatter files the first time an alias is resolved, these are loaded
+// directly so a formatter of the user that fails to load cannot resolve an alias again while the aliases are read
+func resolveAlias(system string) (string, bool) {
+	formatterAliasesOnce.Do(func() {
+		formatterAliases = map[string]string{}
+		names, err := izu.GetFormatterNames("lua")
+		if err != nil {
+			return
+		}
+
+		for _, name := range names {
+			content, err := izu.GetEmbeddedFormatterFile("lua", name)
+			if err != nil {
+				continue
+			}
+			formatter, err := loadFormatter(name, content, "", Config{})
+			if err != nil {
+				continue
+			}
+			for _, alias := range formatter.Metadata().Aliases {
+				formatterAliases[alias] = name
+			}
+			formatter.state.Close()
+		}
+	})
+
+	name, ok := formatterAliases[system]
+	return name, ok
+}
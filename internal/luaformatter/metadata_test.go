@@ -0,0 +1,120 @@
+package luaformatter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+)
+
+func TestMetadata(t *testing.T) {
+	cases := []struct {
+		code     string
+		metadata Metadata
+		err      string
+	}{
+		{
+			``,
+			Metadata{Name: "formatter.lua", Aliases: []string{}},
+			"",
+		},
+		{
+			`formatter.name = "test"
+formatter.description = "a test formatter"
+formatter.version = "1.2.3"
+formatter.aliases = {"other"}
+formatter.mode = "mode"
+formatter.flags = {
+  { name = "release", description = "on release" },
+  { name = "mode", type = "string" },
+}`,
+			Metadata{
+				Name:        "test",
+				Description: "a test formatter",
+				Version:     "1.2.3",
+				Flags:       []Flag{{"release", "bool", "on release"}, {"mode", "string", ""}},
+				Aliases:     []string{"other"},
+				Mode:        "mode",
+			},
+			"",
+		},
+		{`formatter.version = 1`, Metadata{}, "expected 'version' to be a string"},
+		{`formatter.aliases = "other"`, Metadata{}, "expected 'aliases' to be a list of strings"},
+		{`formatter.flags = {"release"}`, Metadata{}, "expected every flag in 'flags' to be a table"},
+		{`formatter.flags = {{ type = "bool" }}`, Metadata{}, "expected every flag in 'flags' to have a name"},
+		{`formatter.flags = {{ name = "mode", type = "list" }}`, Metadata{}, "unknown type 'list'"},
+		{`formatter.mode = "layer"
+formatter.flags = {{ name = "mode", type = "string" }}`, Metadata{}, "expected the mode 'layer' to be one of the string flags"},
+	}
+
+	for case_index, c := range cases {
+		path := filepath.Join(t.TempDir(), "formatter.lua")
+		content := "local formatter = {}\n" + c.code + `
+function formatter.hotkey (args) return args.value end
+function formatter.binding (args) return args.value end
+function formatter.multiple (args) return args.value end
+function formatter.single (args) return args.value end
+function formatter.string (args) return args.value end
+return formatter
+`
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		formatter, err := NewFormatter(path)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("#%d: returned error '%v', want '%s'", case_index, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", case_index, err)
+			continue
+		}
+
+		metadata := formatter.Metadata()
+		// the name defaults to the system, which is the full path of the file here
+		if c.metadata.Name == "formatter.lua" {
+			c.metadata.Name = path
+		}
+		if diff := deep.Equal(metadata, c.metadata); diff != nil {
+			t.Errorf("#%d: %v", case_index, diff)
+		}
+	}
+}
+
+func TestAlias(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	formatter, err := NewFormatter("i3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if formatter.System() != "i3" {
+		t.Errorf("expected system 'i3', got '%s'", formatter.System())
+	}
+	if formatter.Metadata().Name != "sway" || formatter.Name() != "sway" {
+		t.Errorf("expected the sway formatter, got '%s'", formatter.Metadata().Name)
+	}
+
+	// the commands and flags for sway are used for i3, unless there is one for i3 itself
+	hotkeys, err := parser.Parse([]byte("super + q | sway[release]\n  sway | kill\n\nsuper + w | sway[release] i3[locked]\n  sway | kill\n  i3 | exit"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := formatter.Format(hotkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(output, []string{"bindsym --release super+q kill", "bindsym --locked super+w exit"}); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -1,8 +1,10 @@
 package luaformatter
 
 import (
+	"slices"
 	"strings"
 
+	"github.com/meir/izu/pkg/izu"
 	lua "github.com/yuin/gopher-lua"
 )
 
@@ -33,3 +35,208 @@ func contains(state *lua.LState) int {
 	state.Push(lua.LBool(result))
 	return 1
 }
+
+// modifiers maps the names of modifiers, including their aliases, to the name used in izu
+var modifiers = map[string]string{
+	"super":   "super",
+	"mod4":    "super",
+	"win":     "super",
+	"logo":    "super",
+	"ctrl":    "ctrl",
+	"control": "ctrl",
+	"alt":     "alt",
+	"mod1":    "alt",
+	"shift":   "shift",
+	"mod":     "mod",
+	"mod2":    "mod2",
+	"mod3":    "mod3",
+	"mod5":    "mod5",
+	"hyper":   "hyper",
+	"meta":    "meta",
+	"lock":    "lock",
+}
+
+// defaultModifierOrder is the order of the modifiers if the formatter does not give one
+var defaultModifierOrder = []string{"super", "ctrl", "alt", "shift"}
+
+// tableStrings returns the values of a lua table as strings
+func tableStrings(table *lua.LTable) []string {
+	output := []string{}
+	table.ForEach(func(_, value lua.LValue) {
+		output = append(output, value.String())
+	})
+	return output
+}
+
+// newTable creates a lua table with the strings as its values
+func newTable(state *lua.LState, values []string) *lua.LTable {
+	table := state.NewTable()
+	for _, value := range values {
+		table.Append(lua.LString(value))
+	}
+	return table
+}
+
+// readOnlyTable creates a lua table with the values that raises an error when a field is set,
+// the values are looked up through the metatable so the table itself stays empty
+func readOnlyTable(state *lua.LState, name string, values map[string]string) *lua.LTable {
+	fields := state.NewTable()
+	for key, value := range values {
+		fields.RawSetString(key, lua.LString(value))
+	}
+
+	metatable := state.NewTable()
+	metatable.RawSetString("__index", fields)
+	metatable.RawSetString("__newindex", state.NewFunction(func(state *lua.LState) int {
+		state.RaiseError("%s is read-only", name)
+		return 0
+	}))
+	// hide the metatable so the fields cannot be changed through getmetatable
+	metatable.RawSetString("__metatable", lua.LString(name+" is read-only"))
+
+	table := state.NewTable()
+	state.SetMetatable(table, metatable)
+	return table
+}
+
+// split will split a string on every occurrence of the separator
+func split(state *lua.LState) int {
+	str := state.CheckString(1)
+	separator := state.CheckString(2)
+	state.Push(newTable(state, strings.Split(str, separator)))
+	return 1
+}
+
+// join will join the values of a table with the separator, empty strings are skipped
+func join(state *lua.LState) int {
+	table := state.CheckTable(1)
+	separator := state.OptString(2, "")
+	values := []string{}
+	for _, value := range tableStrings(table) {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	state.Push(lua.LString(strings.Join(values, separator)))
+	return 1
+}
+
+// trim will remove the leading and trailing whitespace, or the characters in the cutset if one is given
+func trim(state *lua.LState) int {
+	str := state.CheckString(1)
+	if state.GetTop() < 2 {
+		state.Push(lua.LString(strings.TrimSpace(str)))
+		return 1
+	}
+	state.Push(lua.LString(strings.Trim(str, state.CheckString(2))))
+	return 1
+}
+
+// replace will replace every occurrence of old with new, unlike string.gsub the old string is not a pattern
+func replace(state *lua.LState) int {
+	str := state.CheckString(1)
+	old := state.CheckString(2)
+	replacement := state.CheckString(3)
+	state.Push(lua.LString(strings.ReplaceAll(str, old, replacement)))
+	return 1
+}
+
+// modifier will return the izu name of the modifier, such as ctrl for Control, or nil if the key is not a modifier
+func modifier(state *lua.LState) int {
+	name, ok := modifiers[strings.ToLower(state.CheckString(1))]
+	if !ok {
+		state.Push(lua.LNil)
+		return 1
+	}
+	state.Push(lua.LString(name))
+	return 1
+}
+
+// isModifier will check if the key is a modifier
+func isModifier(state *lua.LState) int {
+	_, ok := modifiers[strings.ToLower(state.CheckString(1))]
+	state.Push(lua.LBool(ok))
+	return 1
+}
+
+// splitModifiers will split the keys into the modifiers and the other keys, empty keys are left out
+// the modifiers are ordered using the names in the optional order table, which defaults to super, ctrl, alt, shift
+func splitModifiers(state *lua.LState) int {
+	keys := tableStrings(state.CheckTable(1))
+	order := defaultModifierOrder
+	if table, ok := state.Get(2).(*lua.LTable); ok {
+		order = tableStrings(table)
+	}
+
+	// modifiers that are not part of the order are placed after the ones that are
+	position := func(key string) int {
+		for i, name := range order {
+			if modifiers[strings.ToLower(name)] == modifiers[strings.ToLower(key)] {
+				return i
+			}
+		}
+		return len(order)
+	}
+
+	held := []string{}
+	pressed := []string{}
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if _, ok := modifiers[strings.ToLower(key)]; ok {
+			held = append(held, key)
+		} else {
+			pressed = append(pressed, key)
+		}
+	}
+	slices.SortStableFunc(held, func(a, b string) int {
+		return position(a) - position(b)
+	})
+
+	state.Push(newTable(state, held))
+	state.Push(newTable(state, pressed))
+	return 2
+}
+
+// orderModifiers will order the keys so the modifiers come first, see splitModifiers
+func orderModifiers(state *lua.LState) int {
+	splitModifiers(state)
+	pressed := state.Get(-1).(*lua.LTable)
+	held := state.Get(-2).(*lua.LTable)
+	state.Pop(2)
+	state.Push(newTable(state, append(tableStrings(held), tableStrings(pressed)...)))
+	return 1
+}
+
+// mapKeys will replace every key that is in the mapping with its value in the mapping
+func mapKeys(state *lua.LState) int {
+	keys := tableStrings(state.CheckTable(1))
+	mapping := state.CheckTable(2)
+	output := []string{}
+	for _, key := range keys {
+		if value := mapping.RawGetString(key); value != lua.LNil {
+			key = value.String()
+		}
+		output = append(output, key)
+	}
+	state.Push(newTable(state, output))
+	return 1
+}
+
+// shellQuote will quote the string so a shell reads it as a single argument
+func shellQuote(state *lua.LState) int {
+	state.Push(lua.LString(izu.ShellQuote(state.CheckString(1))))
+	return 1
+}
+
+// keysym will return the name of the xkb key with the correct capitalization, or nil if it is not a known key
+func keysym(state *lua.LState) int {
+	name, ok := izu.Keysym(state.CheckString(1))
+	if !ok {
+		state.Push(lua.LNil)
+		return 1
+	}
+	state.Push(lua.LString(name))
+	return 1
+}
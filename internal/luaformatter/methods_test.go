@@ -0,0 +1,46 @@
+package luaformatter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMethods(t *testing.T) {
+	cases := []string{
+		`assert(izu.lowercase("Super") == "super")`,
+		`assert(izu.uppercase("a") == "A")`,
+		`assert(izu.contains({"a", "b"}, "b"))`,
+		`assert(#izu.split("a,b,,c", ",") == 4)`,
+		`assert(izu.join({"super", "", "a"}, "+") == "super+a")`,
+		`assert(izu.join({"a", "b"}) == "ab")`,
+		`assert(izu.trim("  a b  ") == "a b")`,
+		`assert(izu.trim("--a--", "-") == "a")`,
+		`assert(izu.replace("a.b.c", ".", "+") == "a+b+c")`,
+		`assert(izu.modifier("Control") == "ctrl")`,
+		`assert(izu.modifier("mod4") == "super")`,
+		`assert(izu.modifier("a") == nil)`,
+		`assert(izu.is_modifier("Shift") and not izu.is_modifier("Shift_L"))`,
+		`local mods, keys = izu.split_modifiers({"shift", "", "a", "super"})
+		 assert(izu.join(mods, "+") == "super+shift" and izu.join(keys, "+") == "a")`,
+		`local mods, keys = izu.split_modifiers({"Ctrl", "Super", "x"}, {"super", "shift", "alt", "ctrl"})
+		 assert(izu.join(mods, "+") == "Super+Ctrl")`,
+		`assert(izu.join(izu.order_modifiers({"a", "alt", "ctrl"}), "+") == "ctrl+alt+a")`,
+		`assert(izu.join(izu.map_keys({"super", "a"}, {super = "Super"}), "+") == "Super+a")`,
+		`assert(izu.shell_quote("foot") == "foot")`,
+		`assert(izu.shell_quote("it's") == "'it'\\''s'")`,
+		`assert(izu.keysym("xf86audioplay") == "XF86AudioPlay")`,
+		`assert(izu.keysym("not a key") == nil)`,
+	}
+
+	for case_index, code := range cases {
+		path := filepath.Join(t.TempDir(), "formatter.lua")
+		if err := os.WriteFile(path, []byte(module(code)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := NewFormatter(path); err != nil {
+			t.Errorf("#%d: '%s' returned error: %v", case_index, code, err)
+		}
+	}
+}
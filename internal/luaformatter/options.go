@@ -30,10 +30,18 @@ func OptionStringArray(values []string) Option {
 	}
 }
 
-func OptionFlags(flags []string) Option {
+// OptionFlags adds the flags as a table, every flag is a positional entry as name or name=value
+// and a named entry which is true for bare flags and the value for key/value flags
+func OptionFlags(flags []izu.Flag) Option {
 	array := &lua.LTable{}
 	for i, flag := range flags {
-		array.RawSetInt(i, lua.LString(flag))
+		// +1 because lua is 1 indexed
+		array.RawSetInt(i+1, lua.LString(flag.Pair()))
+		if flag.HasValue() {
+			array.RawSetString(flag.Name, lua.LString(flag.Value))
+		} else {
+			array.RawSetString(flag.Name, lua.LTrue)
+		}
 	}
 
 	return Option{
@@ -42,6 +50,13 @@ func OptionFlags(flags []string) Option {
 	}
 }
 
+func OptionDefault(isDefault bool) Option {
+	return Option{
+		name:  "default",
+		value: lua.LBool(isDefault),
+	}
+}
+
 func OptionAST(ast izu.AST) Option {
 	return Option{
 		name:  "ast",
@@ -76,3 +91,38 @@ func OptionStateCommand() Option {
 		value: lua.LNumber(2),
 	}
 }
+
+func OptionSystem(system string) Option {
+	return Option{
+		name:  "system",
+		value: lua.LString(system),
+	}
+}
+
+// OptionHotkeys adds every formatted binding with its metadata, used by the document methods
+func OptionHotkeys(bindings []Binding) Option {
+	array := &lua.LTable{}
+	for i, binding := range bindings {
+		lines := &lua.LTable{}
+		for j, line := range binding.Lines {
+			lines.RawSetInt(j+1, lua.LString(line))
+		}
+
+		entry := &lua.LTable{}
+		entry.RawSetString("binding", lua.LString(binding.Binding))
+		entry.RawSetString("command", lua.LString(binding.Command))
+		entry.RawSetString("default", lua.LBool(binding.Default))
+		entry.RawSetString("lines", lines)
+		entry.RawSetString("description", lua.LString(binding.Hotkey.Description))
+		entry.RawSetString("line", lua.LNumber(binding.Hotkey.Line))
+		entry.RawSetString("flags", OptionFlags(binding.Flags).value)
+
+		// +1 because lua is 1 indexed
+		array.RawSetInt(i+1, entry)
+	}
+
+	return Option{
+		name:  "hotkeys",
+		value: array,
+	}
+}
@@ -0,0 +1,107 @@
+package luaformatter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/meir/izu/pkg/izu"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// moduleName is the pattern of the names that can be required, dots separate directories like in lua
+var moduleName = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+
+// source is a place a module can be loaded from
+type source struct {
+	name string
+	load func(name string) ([]byte, error)
+}
+
+// newRequire creates the require function of a formatter, modules are searched for in the directory of the formatter file,
+// then in the embedded lib directory and then in the embedded formatters, so a formatter can extend one of them
+// the formatter file itself is skipped so a formatter called sway.lua can still require the embedded sway formatter
+func newRequire(state *lua.LState, path string, original lua.LValue) *lua.LFunction {
+	sources := []source{}
+	if path != "" {
+		dir := filepath.Dir(path)
+		sources = append(sources, source{dir, func(name string) ([]byte, error) {
+			file := filepath.Join(dir, filepath.FromSlash(name)+".lua")
+			if same, err := filepath.Abs(file); err == nil {
+				if self, err := filepath.Abs(path); err == nil && same == self {
+					return nil, os.ErrNotExist
+				}
+			}
+			return os.ReadFile(file)
+		}})
+	}
+	sources = append(sources,
+		source{"embedded lib", func(name string) ([]byte, error) {
+			return izu.GetFormatterLibrary("lua", name)
+		}},
+		source{"embedded formatters", func(name string) ([]byte, error) {
+			if strings.Contains(name, "/") {
+				return nil, os.ErrNotExist
+			}
+			return izu.GetEmbeddedFormatterFile("lua", name)
+		}},
+	)
+
+	// loaded keeps the value every module returned, modules that are still loading are false to detect loops
+	loaded := map[string]lua.LValue{}
+	return state.NewFunction(func(state *lua.LState) int {
+		name := state.CheckString(1)
+		if value, ok := loaded[name]; ok {
+			if value == lua.LFalse {
+				state.RaiseError("module '%s' requires itself", name)
+			}
+			state.Push(value)
+			return 1
+		}
+		if !moduleName.MatchString(name) {
+			state.RaiseError("invalid module name '%s'", name)
+		}
+
+		for _, source := range sources {
+			content, err := source.load(strings.ReplaceAll(name, ".", "/"))
+			if err != nil {
+				continue
+			}
+
+			function, err := state.Load(strings.NewReader(string(content)), fmt.Sprintf("%s (%s)", name, source.name))
+			if err != nil {
+				state.RaiseError("failed to load module '%s': %s", name, err.Error())
+			}
+
+			loaded[name] = lua.LFalse
+			state.Push(function)
+			state.Call(0, 1)
+			value := state.Get(-1)
+			state.Pop(1)
+			// modules that do not return anything are still loaded, like in lua
+			if value == lua.LNil {
+				value = lua.LTrue
+			}
+			loaded[name] = value
+			state.Push(value)
+			return 1
+		}
+
+		// unsafe formatters can still use the require of lua for modules on the package path
+		if function, ok := original.(*lua.LFunction); ok {
+			state.Push(function)
+			state.Push(lua.LString(name))
+			state.Call(1, 1)
+			return 1
+		}
+
+		names := []string{}
+		for _, source := range sources {
+			names = append(names, source.name)
+		}
+		state.RaiseError("module '%s' not found in %s", name, strings.Join(names, ", "))
+		return 0
+	})
+}
@@ -0,0 +1,99 @@
+package luaformatter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/parser"
+)
+
+func TestRequire(t *testing.T) {
+	files := map[string]string{
+		"helper.lua":   `return { name = "helper" }`,
+		"lib/util.lua": `return { name = "util" }`,
+		"loop.lua":     `return require("loop")`,
+	}
+
+	cases := []struct {
+		code string
+		err  string
+	}{
+		{`assert(require("keys").capitalizations().super == "Super")`, ""},
+		{`assert(require("helper").name == "helper")`, ""},
+		{`assert(require("lib.util").name == "util")`, ""},
+		{`assert(require("helper") == require("helper"))`, ""},
+		{`assert(type(require("sway").dispatch) == "function")`, ""},
+		{`require("missing")`, "module 'missing' not found"},
+		{`require("../helper")`, "invalid module name '../helper'"},
+		{`require("loop")`, "module 'loop' requires itself"},
+	}
+
+	for case_index, c := range cases {
+		dir := t.TempDir()
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		path := filepath.Join(dir, "formatter.lua")
+		if err := os.WriteFile(path, []byte(module(c.code)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := NewFormatter(path)
+		switch {
+		case c.err == "" && err != nil:
+			t.Errorf("#%d: '%s' returned error: %v", case_index, c.code, err)
+		case c.err != "" && err == nil:
+			t.Errorf("#%d: '%s' did not return an error", case_index, c.code)
+		case c.err != "" && !strings.Contains(err.Error(), c.err):
+			t.Errorf("#%d: '%s' returned error '%v', want '%s'", case_index, c.code, err, c.err)
+		}
+	}
+}
+
+func TestRequireExtend(t *testing.T) {
+	// a formatter with the same name as the embedded formatter can still extend it
+	code := `local sway = require("sway")
+local formatter = {}
+for key, value in pairs(sway) do
+  formatter[key] = value
+end
+
+function formatter.hotkey (args)
+  return "# " .. args.value[1] .. "\n" .. sway.hotkey(args)
+end
+
+return formatter`
+
+	path := filepath.Join(t.TempDir(), "sway.lua")
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	hotkeys, err := parser.Parse([]byte("super + t\n  foot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formatter, err := NewFormatter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := formatter.Format(hotkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(output, []string{"# super+t\nbindsym super+t exec foot"}); diff != nil {
+		t.Error(diff)
+	}
+}
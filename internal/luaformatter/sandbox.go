@@ -0,0 +1,92 @@
+package luaformatter
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// DefaultTimeout is the time a single call into the lua formatter may take before it is stopped
+const DefaultTimeout = 5 * time.Second
+
+// Config changes how the lua formatter is run, the zero value runs the formatter sandboxed with the default timeout
+type Config struct {
+	// Unsafe opens every lua library, including os and io, so the formatter can run commands and access files
+	Unsafe bool
+	// Timeout is the time a single call into the formatter may take, 0 uses DefaultTimeout
+	Timeout time.Duration
+	// Options are the key/value options given to the formatter, these are available as izu.options
+	Options map[string]string
+	// Strict makes Format return an error for flags the formatter does not accept, instead of warning about them
+	Strict bool
+}
+
+// safeLibraries are the lua libraries that cannot reach outside of the formatter
+var safeLibraries = map[string]lua.LGFunction{
+	lua.BaseLibName:   lua.OpenBase,
+	lua.TabLibName:    lua.OpenTable,
+	lua.StringLibName: lua.OpenString,
+	lua.MathLibName:   lua.OpenMath,
+}
+
+// unsafeGlobals are the functions of the base library that load files or modules from disk,
+// require is replaced afterwards by the require of the formatter, see newRequire
+var unsafeGlobals = []string{"dofile", "loadfile", "module", "require"}
+
+// newState creates the lua state for a formatter, only the safe libraries are opened unless the config is unsafe
+func newState(config Config) *lua.LState {
+	if config.Unsafe {
+		state := lua.NewState()
+		state.SetGlobal("print", state.NewFunction(debugPrint))
+		return state
+	}
+
+	state := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for name, open := range safeLibraries {
+		state.Push(state.NewFunction(open))
+		state.Push(lua.LString(name))
+		state.Call(1, 0)
+	}
+	for _, name := range unsafeGlobals {
+		state.SetGlobal(name, lua.LNil)
+	}
+
+	// os is replaced by the functions that only read the clock
+	state.Push(state.NewFunction(lua.OpenOs))
+	state.Call(0, 1)
+	full := state.Get(-1).(*lua.LTable)
+	state.Pop(1)
+
+	os := state.NewTable()
+	for _, name := range []string{"clock", "date", "time", "difftime"} {
+		os.RawSetString(name, full.RawGetString(name))
+	}
+	state.SetGlobal(lua.OsLibName, os)
+	state.SetGlobal("print", state.NewFunction(debugPrint))
+
+	return state
+}
+
+// debugPrint replaces the print of the base library, which writes to stdout where it would end up in the generated config,
+// the values are logged at debug level instead
+func debugPrint(state *lua.LState) int {
+	values := []string{}
+	for i := 1; i <= state.GetTop(); i++ {
+		values = append(values, state.ToStringMeta(state.Get(i)).String())
+	}
+	slog.Debug("Lua formatter printed", "output", strings.Join(values, "\t"))
+	return 0
+}
+
+// run calls the function with a context that stops the lua state once the timeout of the config has passed
+func (formatter *Formatter) run(fn func() error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), formatter.timeout)
+	defer cancel()
+
+	formatter.state.SetContext(ctx)
+	defer formatter.state.RemoveContext()
+	return fn()
+}
@@ -0,0 +1,111 @@
+package luaformatter
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// module is a formatter module that returns the values of its fields as is, with the given code in front of it
+func module(code string) string {
+	return code + `
+local formatter = {}
+function formatter.hotkey (args) return args.value end
+function formatter.binding (args) return args.value end
+function formatter.multiple (args) return args.value end
+function formatter.single (args) return args.value end
+function formatter.string (args) return args.value end
+return formatter
+`
+}
+
+func TestSandbox(t *testing.T) {
+	cases := []struct {
+		code   string
+		config Config
+		err    string
+	}{
+		{`local now = os.time()`, Config{}, ""},
+		{`os.execute("true")`, Config{}, "attempt to call a non-function object"},
+		{`local file = io.open("/etc/hostname")`, Config{}, "attempt to index a non-table object"},
+		{`dofile("/etc/hostname")`, Config{}, "attempt to call a non-function object"},
+		{`loadfile("/etc/hostname")`, Config{}, "attempt to call a non-function object"},
+		{`while true do end`, Config{Timeout: 50 * time.Millisecond}, "context deadline exceeded"},
+		{`os.execute("true")`, Config{Unsafe: true}, ""},
+	}
+
+	for case_index, c := range cases {
+		path := filepath.Join(t.TempDir(), "formatter.lua")
+		if err := os.WriteFile(path, []byte(module(c.code)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := NewFormatterWithConfig(path, c.config)
+		switch {
+		case c.err == "" && err != nil:
+			t.Errorf("#%d: '%s' returned error: %v", case_index, c.code, err)
+		case c.err != "" && err == nil:
+			t.Errorf("#%d: '%s' did not return an error", case_index, c.code)
+		case c.err != "" && !strings.Contains(err.Error(), c.err):
+			t.Errorf("#%d: '%s' returned error '%v', want '%s'", case_index, c.code, err, c.err)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	cases := []struct {
+		code    string
+		options map[string]string
+		err     string
+	}{
+		{`assert(izu.options.exec == "exec --no-startup-id")`, map[string]string{"exec": "exec --no-startup-id"}, ""},
+		{`assert(izu.options.exec == nil)`, nil, ""},
+		{`izu.options.exec = "exec"`, nil, "izu.options is read-only"},
+		{`rawset(getmetatable(izu.options), "__newindex", nil)`, nil, "bad argument #1 to rawset"},
+	}
+
+	for case_index, c := range cases {
+		path := filepath.Join(t.TempDir(), "formatter.lua")
+		if err := os.WriteFile(path, []byte(module(c.code)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := NewFormatterWithConfig(path, Config{Options: c.options})
+		switch {
+		case c.err == "" && err != nil:
+			t.Errorf("#%d: '%s' returned error: %v", case_index, c.code, err)
+		case c.err != "" && err == nil:
+			t.Errorf("#%d: '%s' did not return an error", case_index, c.code)
+		case c.err != "" && !strings.Contains(err.Error(), c.err):
+			t.Errorf("#%d: '%s' returned error '%v', want '%s'", case_index, c.code, err, c.err)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	// print would write to stdout, where it ends up in the generated config
+	buffer := &bytes.Buffer{}
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(previous)
+
+	for case_index, config := range []Config{{}, {Unsafe: true}} {
+		buffer.Reset()
+		path := filepath.Join(t.TempDir(), "formatter.lua")
+		if err := os.WriteFile(path, []byte(module(`print("hello", 1)`)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := NewFormatterWithConfig(path, config); err != nil {
+			t.Errorf("#%d: returned error: %v", case_index, err)
+			continue
+		}
+		if !strings.Contains(buffer.String(), `output="hello\t1"`) {
+			t.Errorf("#%d: print was not logged, got '%s'", case_index, buffer.String())
+		}
+	}
+}
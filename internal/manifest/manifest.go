@@ -0,0 +1,122 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Manifest describes all the targets that are generated from a single config
+type Manifest struct {
+	Config  string    `json:"config"`
+	Targets []*Target `json:"targets"`
+}
+
+// Target is a single formatter output of the manifest
+type Target struct {
+	Name      string   `json:"name"`
+	Formatter string   `json:"formatter"`
+	Output    string   `json:"output"`
+	Inject    string   `json:"inject"`
+	Backup    bool     `json:"backup"`
+	Tags      []string `json:"tags"`
+	Reload    string   `json:"reload"`
+	// Options are given to the formatter as izu.options
+	Options map[string]string `json:"options"`
+}
+
+// Load reads the manifest from the path and validates it
+// relative paths in the manifest are resolved from the directory of the manifest
+func Load(path string) (*Manifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	if manifest.Config == "" {
+		return nil, fmt.Errorf("manifest does not contain a config")
+	}
+	dir := filepath.Dir(path)
+	manifest.Config = resolve(dir, manifest.Config)
+
+	names := map[string]bool{}
+	for i, target := range manifest.Targets {
+		if target.Formatter == "" {
+			return nil, fmt.Errorf("target #%d does not have a formatter", i)
+		}
+		// the name defaults to the formatter, so targets that use the same formatter have to be named
+		unnamed := target.Name == ""
+		if unnamed {
+			target.Name = target.Formatter
+		}
+		if names[target.Name] && unnamed {
+			return nil, fmt.Errorf("target #%d is named '%s' after its formatter, which is the name of another target, give it a name", i, target.Name)
+		}
+		if names[target.Name] {
+			return nil, fmt.Errorf("target '%s' is defined more than once", target.Name)
+		}
+		names[target.Name] = true
+
+		if target.Output != "" && target.Inject != "" {
+			return nil, fmt.Errorf("target '%s' cannot have both an output and inject", target.Name)
+		}
+		if target.Output == "" && target.Inject == "" {
+			return nil, fmt.Errorf("target '%s' needs either an output or inject", target.Name)
+		}
+		// formatters can be given by name or as a path to a lua file
+		if strings.ContainsRune(target.Formatter, '/') || strings.HasSuffix(target.Formatter, ".lua") {
+			target.Formatter = resolve(dir, target.Formatter)
+		}
+		if target.Output != "" {
+			target.Output = resolve(dir, target.Output)
+		}
+		if target.Inject != "" {
+			target.Inject = resolve(dir, target.Inject)
+		}
+	}
+
+	return manifest, nil
+}
+
+// Select returns the targets that have at least one of the tags, or all targets if no tags are given
+func (m *Manifest) Select(tags []string) []*Target {
+	if len(tags) == 0 {
+		return m.Targets
+	}
+
+	targets := []*Target{}
+	for _, target := range m.Targets {
+		for _, tag := range tags {
+			if slices.Contains(target.Tags, tag) {
+				targets = append(targets, target)
+				break
+			}
+		}
+	}
+	return targets
+}
+
+// resolve expands environment variables and ~ in the path and makes it relative to the directory
+func resolve(dir, path string) string {
+	path = os.ExpandEnv(path)
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	return path
+}
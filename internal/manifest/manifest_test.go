@@ -0,0 +1,90 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "izu.json")
+	err := os.WriteFile(path, []byte(`{
+  "config": "hotkeys",
+  "targets": [
+    { "formatter": "sway", "output": "sway/hotkeys", "tags": ["laptop"], "reload": "swaymsg reload", "options": { "exec": "exec --no-startup-id" } },
+    { "name": "hypr", "formatter": "hyprland", "inject": "/etc/hypr/hyprland.conf", "tags": ["desktop"] },
+    { "formatter": "sxhkd", "output": "sxhkdrc", "tags": ["laptop", "desktop"] }
+  ]
+}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if manifest.Config != filepath.Join(dir, "hotkeys") {
+		t.Errorf("config is '%s', want it relative to the manifest", manifest.Config)
+	}
+	if manifest.Targets[0].Name != "sway" || manifest.Targets[0].Output != filepath.Join(dir, "sway/hotkeys") {
+		t.Errorf("unexpected first target %+v", manifest.Targets[0])
+	}
+	if manifest.Targets[0].Options["exec"] != "exec --no-startup-id" {
+		t.Errorf("unexpected options %v", manifest.Targets[0].Options)
+	}
+	if manifest.Targets[1].Inject != "/etc/hypr/hyprland.conf" {
+		t.Errorf("absolute inject path changed to '%s'", manifest.Targets[1].Inject)
+	}
+
+	cases := []struct {
+		tags    []string
+		targets []string
+	}{
+		{nil, []string{"sway", "hypr", "sxhkd"}},
+		{[]string{"laptop"}, []string{"sway", "sxhkd"}},
+		{[]string{"desktop", "laptop"}, []string{"sway", "hypr", "sxhkd"}},
+		{[]string{"server"}, []string{}},
+	}
+	for i, c := range cases {
+		targets := manifest.Select(c.tags)
+		if len(targets) != len(c.targets) {
+			t.Errorf("#%d: selected %d targets, want %d", i, len(targets), len(c.targets))
+			continue
+		}
+		for j, target := range targets {
+			if target.Name != c.targets[j] {
+				t.Errorf("#%d: selected '%s', want '%s'", i, target.Name, c.targets[j])
+			}
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	cases := []struct {
+		content string
+		err     string
+	}{
+		{`{"targets": [{"formatter": "sway", "output": "a"}]}`, "does not contain a config"},
+		{`{"config": "c", "targets": [{"output": "a"}]}`, "does not have a formatter"},
+		{`{"config": "c", "targets": [{"formatter": "sway"}]}`, "needs either an output or inject"},
+		{`{"config": "c", "targets": [{"formatter": "sway", "output": "a", "inject": "b"}]}`, "both an output and inject"},
+		{`{"config": "c", "targets": [{"formatter": "sway", "output": "a"}, {"formatter": "sway", "output": "b"}]}`, "target #1 is named 'sway' after its formatter"},
+		{`{"config": "c", "targets": [{"formatter": "sway", "output": "a"}, {"name": "sway", "formatter": "hyprland", "output": "b"}]}`, "target 'sway' is defined more than once"},
+		{`{"config": "c", "typo": true}`, "unknown field"},
+	}
+
+	for i, c := range cases {
+		path := filepath.Join(t.TempDir(), "izu.json")
+		if err := os.WriteFile(path, []byte(c.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Load(path)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("#%d: returned error '%v', want '%s'", i, err, c.err)
+		}
+	}
+}
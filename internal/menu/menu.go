@@ -0,0 +1,66 @@
+package menu
+
+import (
+	"strings"
+	"text/tabwriter"
+
+	"github.com/meir/izu/internal/luaformatter"
+)
+
+// combos returns a binding for every key combination, formatters that keep the multiples in the binding, such as sxhkd,
+// have a single binding for several combinations, these are split into the keys and the command of each combination
+func combos(bindings []luaformatter.Binding) []luaformatter.Binding {
+	output := []luaformatter.Binding{}
+	for _, binding := range bindings {
+		if len(binding.Combos) <= 1 {
+			output = append(output, binding)
+			continue
+		}
+		for _, combo := range binding.Combos {
+			split := binding
+			split.Binding = strings.Join(combo.Keys, " + ")
+			split.Command = combo.Command
+			split.Combos = []luaformatter.Combo{combo}
+			output = append(output, split)
+		}
+	}
+	return output
+}
+
+// Lines returns a line for every key combination with its keys, the description of the hotkey and the command
+// the columns are aligned so the lines can be searched in launchers such as dmenu, rofi or fzf
+func Lines(bindings []luaformatter.Binding) []string {
+	bindings = combos(bindings)
+	if len(bindings) == 0 {
+		return []string{}
+	}
+
+	builder := &strings.Builder{}
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+	for _, binding := range bindings {
+		// tabs and newlines would break the columns and lines
+		columns := []string{binding.Binding, binding.Hotkey.Description, binding.Command}
+		for i, column := range columns {
+			columns[i] = strings.Join(strings.Fields(column), " ")
+		}
+		writer.Write([]byte(strings.Join(columns, "\t") + "\n"))
+	}
+	writer.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(builder.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return lines
+}
+
+// Find returns the binding of the line selected in the launcher, with the command of the selected key combination
+func Find(bindings []luaformatter.Binding, selected string) (luaformatter.Binding, bool) {
+	selected = strings.TrimSpace(selected)
+	for i, line := range Lines(bindings) {
+		if line == selected {
+			return combos(bindings)[i], true
+		}
+	}
+	return luaformatter.Binding{}, false
+}
@@ -0,0 +1,103 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+)
+
+func TestMenu(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	input := `# open a terminal
+super + Return
+  foot
+
+super + {h,l}
+  sway | focus {left,right}`
+
+	hotkeys, err := parser.Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formatter, err := luaformatter.NewFormatter("sway")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bindings, _, err := formatter.Expand(hotkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := Lines(bindings)
+	expected := []string{
+		"super+Return  open a terminal  foot",
+		"super+h                        focus left",
+		"super+l                        focus right",
+	}
+	if diff := deep.Equal(lines, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	cases := []struct {
+		selected string
+		command  string
+	}{
+		{"super+Return  open a terminal  foot\n", "foot"},
+		{"super+l                        focus right", "focus right"},
+		{"super+q", ""},
+	}
+
+	for case_index, c := range cases {
+		binding, ok := Find(bindings, c.selected)
+		if ok != (c.command != "") {
+			t.Errorf("#%d: '%s' found %v", case_index, c.selected, ok)
+			continue
+		}
+		if ok && binding.Command != c.command {
+			t.Errorf("#%d: '%s' selected '%s', want '%s'", case_index, c.selected, binding.Command, c.command)
+		}
+	}
+}
+
+func TestMenuCombos(t *testing.T) {
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	hotkeys, err := parser.Parse([]byte("super + XF86Audio{Play,Pause}\n  playerctl --{play,pause}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sxhkd keeps the multiples in a single binding, the menu has a line for every combination
+	formatter, err := luaformatter.NewFormatter("sxhkd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bindings, _, err := formatter.Expand(hotkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"super + XF86AudioPlay     playerctl --play",
+		"super + XF86AudioPause    playerctl --pause",
+	}
+	if diff := deep.Equal(Lines(bindings), expected); diff != nil {
+		t.Error(diff)
+	}
+
+	binding, ok := Find(bindings, expected[1])
+	if !ok || binding.Command != "playerctl --pause" {
+		t.Errorf("selected '%s', want 'playerctl --pause'", binding.Command)
+	}
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// BeginMarker marks the line after which the managed block starts, it can be in any kind of comment
+	BeginMarker = "BEGIN IZU MANAGED BLOCK"
+	// EndMarker marks the line before which the managed block ends
+	EndMarker = "END IZU MANAGED BLOCK"
+)
+
+// Inject replaces everything between the marker lines in the target with the content
+// the marker lines themselves and everything outside of them are left untouched
+// an error is returned if either marker is missing, found more than once or in the wrong order
+func Inject(target, content []byte) ([]byte, error) {
+	lines := strings.SplitAfter(string(target), "\n")
+
+	begin, end := -1, -1
+	for i, line := range lines {
+		if strings.Contains(line, BeginMarker) {
+			if begin != -1 {
+				return nil, fmt.Errorf("marker '%s' found more than once, on line %d and %d", BeginMarker, begin+1, i+1)
+			}
+			begin = i
+		}
+		if strings.Contains(line, EndMarker) {
+			if end != -1 {
+				return nil, fmt.Errorf("marker '%s' found more than once, on line %d and %d", EndMarker, end+1, i+1)
+			}
+			end = i
+		}
+	}
+
+	switch {
+	case begin == -1:
+		return nil, fmt.Errorf("marker '%s' not found", BeginMarker)
+	case end == -1:
+		return nil, fmt.Errorf("marker '%s' not found", EndMarker)
+	case end < begin:
+		return nil, fmt.Errorf("marker '%s' on line %d comes before marker '%s' on line %d", EndMarker, end+1, BeginMarker, begin+1)
+	}
+
+	output := strings.Builder{}
+	for _, line := range lines[:begin+1] {
+		output.WriteString(line)
+	}
+	// the begin marker might be the last line without a newline, which would put the content on the same line
+	if !strings.HasSuffix(lines[begin], "\n") {
+		output.WriteString("\n")
+	}
+	output.Write(content)
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		output.WriteString("\n")
+	}
+	for _, line := range lines[end:] {
+		output.WriteString(line)
+	}
+
+	return []byte(output.String()), nil
+}
@@ -0,0 +1,65 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestInject(t *testing.T) {
+	cases := []struct {
+		target  string
+		content string
+
+		output string
+		err    bool
+	}{
+		{
+			target:  "set $mod Mod4\n# BEGIN IZU MANAGED BLOCK\nold\n# END IZU MANAGED BLOCK\nbar {}\n",
+			content: "bindsym Mod4+w exec walld\n",
+			output:  "set $mod Mod4\n# BEGIN IZU MANAGED BLOCK\nbindsym Mod4+w exec walld\n# END IZU MANAGED BLOCK\nbar {}\n",
+		},
+		{
+			target:  "binds {\n  // BEGIN IZU MANAGED BLOCK\n  // END IZU MANAGED BLOCK\n}",
+			content: "  Mod+T { spawn \"foot\"; }",
+			output:  "binds {\n  // BEGIN IZU MANAGED BLOCK\n  Mod+T { spawn \"foot\"; }\n  // END IZU MANAGED BLOCK\n}",
+		},
+		{
+			target:  "# BEGIN IZU MANAGED BLOCK\nold\n# END IZU MANAGED BLOCK",
+			content: "",
+			output:  "# BEGIN IZU MANAGED BLOCK\n# END IZU MANAGED BLOCK",
+		},
+		{
+			target: "no markers\n",
+			err:    true,
+		},
+		{
+			target: "# BEGIN IZU MANAGED BLOCK\n",
+			err:    true,
+		},
+		{
+			target: "# BEGIN IZU MANAGED BLOCK\n# END IZU MANAGED BLOCK\n# BEGIN IZU MANAGED BLOCK\n# END IZU MANAGED BLOCK\n",
+			err:    true,
+		},
+		{
+			target: "# END IZU MANAGED BLOCK\n# BEGIN IZU MANAGED BLOCK\n",
+			err:    true,
+		},
+	}
+
+	for i, c := range cases {
+		output, err := Inject([]byte(c.target), []byte(c.content))
+		if c.err {
+			if err == nil {
+				t.Errorf("#%d: expected an error, got '%s'", i, output)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", i, err)
+			continue
+		}
+		if string(output) != c.output {
+			t.Errorf("#%d: got %q, want %q", i, output, c.output)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix is added to the path of the output file to store the previous generation
+const BackupSuffix = ".bak"
+
+// Write writes the content to the path by writing a temporary file next to it and renaming it over the path,
+// this way the file is never partially written when a daemon reads it
+// if the file already has the same content nothing is written and false is returned
+// if backup is true, the previous content is kept in the same path with BackupSuffix
+func Write(path string, content []byte, backup bool) (bool, error) {
+	mode := fs.FileMode(0644)
+	previous, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if bytes.Equal(previous, content) {
+			return false, nil
+		}
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		// nothing to compare or backup, the file will be created
+		previous = nil
+	default:
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if backup && previous != nil {
+		if err := atomicWrite(path+BackupSuffix, previous, mode); err != nil {
+			return false, fmt.Errorf("failed to write backup: %w", err)
+		}
+	}
+
+	if err := atomicWrite(path, content, mode); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// atomicWrite writes the content to a temporary file in the same directory and renames it to the path
+// a symlinked path, such as a dotfile managed by stow or home-manager, is resolved so the file it points to is replaced
+// instead of the symlink
+func atomicWrite(path string, content []byte, mode fs.FileMode) error {
+	resolved, err := filepath.EvalSymlinks(path)
+	switch {
+	case err == nil:
+		path = resolved
+	case !errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	// remove the temporary file if anything fails, after the rename this does nothing
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if err := os.Chmod(file.Name(), mode); err != nil {
+		return fmt.Errorf("failed to set permissions of temporary file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+
+	steps := []struct {
+		content string
+		backup  bool
+
+		changed bool
+		bak     string
+	}{
+		{content: "first", changed: true},
+		{content: "first", changed: false},
+		{content: "second", backup: true, changed: true, bak: "first"},
+		{content: "second", backup: true, changed: false, bak: "first"},
+		{content: "third", changed: true, bak: "first"},
+	}
+
+	for i, step := range steps {
+		changed, err := Write(path, []byte(step.content), step.backup)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if changed != step.changed {
+			t.Errorf("#%d: changed is %v, want %v", i, changed, step.changed)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil || string(content) != step.content {
+			t.Errorf("#%d: file contains '%s', want '%s' (%v)", i, content, step.content, err)
+		}
+
+		bak, _ := os.ReadFile(path + BackupSuffix)
+		if string(bak) != step.bak {
+			t.Errorf("#%d: backup contains '%s', want '%s'", i, bak, step.bak)
+		}
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 2 {
+		t.Errorf("expected only the file and its backup, found %d files", len(entries))
+	}
+}
+
+func TestWriteSymlink(t *testing.T) {
+	// the output is a symlink into a dotfile repository, like stow or home-manager create
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "config")
+	path := filepath.Join(dir, "config")
+	if err := os.Mkdir(filepath.Dir(target), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, []byte("first"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, path); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Write(path, []byte("second"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	if info, err := os.Lstat(path); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("output is no longer a symlink (%v)", err)
+	}
+	if content, err := os.ReadFile(target); err != nil || string(content) != "second" {
+		t.Errorf("symlinked file contains '%s', want 'second' (%v)", content, err)
+	}
+	if bak, err := os.ReadFile(path + BackupSuffix); err != nil || string(bak) != "first" {
+		t.Errorf("backup contains '%s', want 'first' (%v)", bak, err)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(target))
+	if len(entries) != 1 {
+		t.Errorf("expected only the symlinked file in its directory, found %d files", len(entries))
+	}
+}
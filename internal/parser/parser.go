@@ -186,6 +186,9 @@ func stateRoot(tokenizer *Tokenizer, hotkeys *[]*izu.Hotkey, state *ParserState)
 
 // stateBinding is the parser state for the binding of the parser
 func stateBinding(tokenizer *Tokenizer, hotkeys *[]*izu.Hotkey, state *ParserState) error {
+	// the comment directly above the binding describes the hotkey
+	description := tokenizer.Comment()
+
 	// get the content of the binding, bindings will always end with either a semicolon, a newline of a pipe to specify the flags
 	binding, token := tokenizer.Until(TokenSemicolon, TokenNewLine, TokenSystem)
 
@@ -198,9 +201,11 @@ func stateBinding(tokenizer *Tokenizer, hotkeys *[]*izu.Hotkey, state *ParserSta
 
 	// add the hotkey, subsequent states will fill this hotkey further
 	*hotkeys = append(*hotkeys, &izu.Hotkey{
-		Binding: bindingPart,
-		Command: map[string]izu.Part{},
-		Flags:   map[string][]string{},
+		Binding:     bindingPart,
+		Command:     map[string]izu.Part{},
+		Flags:       map[string][]izu.Flag{},
+		Line:        binding[0].line,
+		Description: description,
 	})
 
 	switch token.Kind() {
@@ -218,11 +223,10 @@ func stateBinding(tokenizer *Tokenizer, hotkeys *[]*izu.Hotkey, state *ParserSta
 
 // stateFlags is the parser state for the flags of the parser
 // we parse the states directly, since its fairly easy
-// the format is always | ([A-Za-z0-9_-]+\[([A-Za-z0-9_-]]+)+\])+
+// the format is always | (system\[(flag|flag=value|flag="quoted value")+\])+
 func stateFlags(tokenizer *Tokenizer, hotkeys *[]*izu.Hotkey, state *ParserState) error {
-	flags := map[string][]string{}
+	flags := map[string][]izu.Flag{}
 	name := ""
-	values := []string{}
 
 FlagLoop:
 	for tokenizer.Next() {
@@ -243,23 +247,9 @@ FlagLoop:
 				return unexpectedToken(next, *state)
 			}
 		case TokenFlagOpen:
-			values = []string{}
-
-			// call next so that TokenFlagOpen token wont be included in parsing the flag items
-			tokenizer.Next()
-			flaglist, _ := tokenizer.Until(TokenFlagClose)
-			for _, flag := range flaglist {
-				// if theres an empty token, just skip it
-				if flag.Kind() == TokenEmpty {
-					continue
-				}
-
-				// if theres a token thats not an id, error
-				if flag.Kind() != TokenString {
-					return unexpectedToken(flag, *state)
-				}
-				// add the flag to the current system
-				values = append(values, flag.String())
+			values, err := parseFlags(tokenizer, *state)
+			if err != nil {
+				return err
 			}
 
 			// if the flag already exists, that means the user specified the system twice, error on this
@@ -271,7 +261,6 @@ FlagLoop:
 			// and reset the name for the next iteration
 			flags[name] = values
 			name = ""
-			values = []string{}
 
 		case TokenNewLine, TokenSemicolon:
 			// if theres a new line or a semicolon, skip to the command state
@@ -284,6 +273,89 @@ FlagLoop:
 	return nil
 }
 
+// parseFlags parses the flags between the brackets of a system, the tokenizer should be at the opening bracket
+// flags are separated by spaces and are either a bare name or a name with a value, values that contain
+// spaces or special characters are quoted, in which case \" and \\ can be used within the quotes
+func parseFlags(tokenizer *Tokenizer, state ParserState) ([]izu.Flag, error) {
+	flags := []izu.Flag{}
+	open := tokenizer.Current()
+
+	var flag *izu.Flag
+	hasValue := false
+	quoted, escaped := false, false
+	var quote Token
+
+	// end adds the current flag to the list, flags with an = should always have a value
+	end := func(token Token) error {
+		if flag == nil {
+			return nil
+		}
+		if hasValue && flag.Value == "" {
+			return fmt.Errorf("flag '%s' has no value at %s", flag.Name, token.Position())
+		}
+		flags = append(flags, *flag)
+		flag, hasValue = nil, false
+		return nil
+	}
+
+	for tokenizer.Next() {
+		token := tokenizer.Current()
+
+		// everything within quotes is part of the value, until the closing quote
+		if quoted {
+			switch {
+			case token.Kind() == TokenNewLine:
+				return nil, fmt.Errorf("unterminated quote at %s", quote.Position())
+			case escaped:
+				escaped = false
+				if !token.Match(`"`) && !token.Match(`\`) {
+					flag.Value += `\`
+				}
+				flag.Value += token.String()
+			case token.Match(`\`):
+				escaped = true
+			case token.Match(`"`):
+				quoted = false
+				// a quoted value is always the end of the flag
+				if next := tokenizer.Peek(); next.Kind() != TokenEmpty && next.Kind() != TokenFlagClose {
+					return nil, unexpectedToken(next, state)
+				}
+			default:
+				flag.Value += token.String()
+			}
+			continue
+		}
+
+		switch {
+		case token.Kind() == TokenFlagClose:
+			if err := end(token); err != nil {
+				return nil, err
+			}
+			return flags, nil
+		case token.Kind() == TokenEmpty:
+			if err := end(token); err != nil {
+				return nil, err
+			}
+		case flag == nil && token.Kind() == TokenString:
+			flag = &izu.Flag{Name: token.String()}
+		case flag != nil && !hasValue && token.Match("="):
+			hasValue = true
+		case hasValue && flag.Value == "" && token.Match(`"`):
+			quoted = true
+			quote = token
+		case hasValue && (token.Kind() == TokenString || token.Kind() == TokenOther) && !token.Match(`"`):
+			flag.Value += token.String()
+		default:
+			return nil, unexpectedToken(token, state)
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote at %s", quote.Position())
+	}
+	return nil, fmt.Errorf("flags opened at %s are never closed", open.Position())
+}
+
 // stateCommand is the parser state for the command of the parser
 func stateCommand(tokenizer *Tokenizer, hotkeys *[]*izu.Hotkey, state *ParserState) error {
 	// check up to the next newline or for a pipe
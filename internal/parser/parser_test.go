@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/go-test/deep"
@@ -39,7 +40,8 @@ func TestParser(t *testing.T) {
 							),
 						},
 					},
-					Flags: map[string][]string{},
+					Flags: map[string][]izu.Flag{},
+					Line:  1,
 				},
 			},
 		},
@@ -63,9 +65,10 @@ func TestParser(t *testing.T) {
 							),
 						},
 					},
-					Flags: map[string][]string{
-						"test": {"left"},
+					Flags: map[string][]izu.Flag{
+						"test": {{Name: "left"}},
 					},
+					Line: 1,
 				},
 			},
 		},
@@ -89,9 +92,10 @@ func TestParser(t *testing.T) {
 							),
 						},
 					},
-					Flags: map[string][]string{
-						"test": {"right"},
+					Flags: map[string][]izu.Flag{
+						"test": {{Name: "right"}},
 					},
+					Line: 1,
 				},
 			},
 		},
@@ -148,9 +152,10 @@ func TestParser(t *testing.T) {
 							),
 						},
 					},
-					Flags: map[string][]string{
-						"test": {"right"},
+					Flags: map[string][]izu.Flag{
+						"test": {{Name: "right"}},
 					},
+					Line: 1,
 				},
 			},
 		},
@@ -236,9 +241,10 @@ func TestParser(t *testing.T) {
 							),
 						},
 					},
-					Flags: map[string][]string{
-						"test": {"right"},
+					Flags: map[string][]izu.Flag{
+						"test": {{Name: "right"}},
 					},
+					Line: 1,
 				},
 			},
 		},
@@ -267,3 +273,128 @@ func TestParser(t *testing.T) {
 		}
 	}
 }
+
+func TestParserDescriptions(t *testing.T) {
+	input := `# not a description
+
+# open a terminal
+#   in the home directory
+super + Return
+  foot
+
+super + w
+  walld
+
+# take a screenshot
+  # of the whole screen
+Print; grim`
+
+	hotkeys, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	descriptions := []string{"open a terminal in the home directory", "", "take a screenshot of the whole screen"}
+	if len(hotkeys) != len(descriptions) {
+		t.Fatalf("returned %d hotkeys, want %d", len(hotkeys), len(descriptions))
+	}
+	for i, hotkey := range hotkeys {
+		if hotkey.Description != descriptions[i] {
+			t.Errorf("#%d: hotkey has description '%s', want '%s'", i, hotkey.Description, descriptions[i])
+		}
+	}
+}
+
+func TestParserLines(t *testing.T) {
+	input := `# comment
+super + w
+  walld
+
+super + {_,shift +} s ; save {_,-f}
+
+super + p | hyprland[l]
+  echo "flag"`
+
+	hotkeys, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []int{2, 5, 7}
+	if len(hotkeys) != len(lines) {
+		t.Fatalf("returned %d hotkeys, want %d", len(hotkeys), len(lines))
+	}
+	for i, hotkey := range hotkeys {
+		if hotkey.Line != lines[i] {
+			t.Errorf("#%d: hotkey is on line %d, want %d", i, hotkey.Line, lines[i])
+		}
+	}
+}
+
+func TestParserFlags(t *testing.T) {
+	cases := []struct {
+		input string
+		flags map[string][]izu.Flag
+		err   string
+	}{
+		{
+			input: "super + a | sway[release mode=resize]\n  foot",
+			flags: map[string][]izu.Flag{"sway": {{Name: "release"}, {Name: "mode", Value: "resize"}}},
+		},
+		{
+			input: "super + a | sway[input-device=1:1:keyboard] hyprland[submap=launcher]\n  foot",
+			flags: map[string][]izu.Flag{
+				"sway":     {{Name: "input-device", Value: "1:1:keyboard"}},
+				"hyprland": {{Name: "submap", Value: "launcher"}},
+			},
+		},
+		{
+			input: `super + a | sway[mode="System (l) lock" locked]` + "\n  foot",
+			flags: map[string][]izu.Flag{"sway": {{Name: "mode", Value: "System (l) lock"}, {Name: "locked"}}},
+		},
+		{
+			input: `super + a | niri[hotkey-overlay-title="say \"hi\" [\\]"]` + "\n  foot",
+			flags: map[string][]izu.Flag{"niri": {{Name: "hotkey-overlay-title", Value: `say "hi" [\]`}}},
+		},
+		{
+			input: "super + a | sway[mode=]\n  foot",
+			err:   "flag 'mode' has no value",
+		},
+		{
+			input: "super + a | sway[mode=\"resize]\n  foot",
+			err:   "unterminated quote",
+		},
+		{
+			input: "super + a | sway[mode=\"resize\"x]\n  foot",
+			err:   "unexpected token 'x'",
+		},
+	}
+
+	for case_index, c := range cases {
+		hotkeys, err := Parse([]byte(c.input))
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("#%d: returned error '%v', want '%s'", case_index, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("#%d: '%s' returned error: %v", case_index, c.input, err)
+			continue
+		}
+
+		if diff := deep.Equal(hotkeys[0].Flags, c.flags); diff != nil {
+			t.Errorf("#%d: %v", case_index, diff)
+		}
+
+		// printing the hotkey should give flags that parse to the same values
+		reparsed, err := Parse([]byte(hotkeys[0].String()))
+		if err != nil {
+			t.Errorf("#%d: '%s' returned error: %v", case_index, hotkeys[0].String(), err)
+			continue
+		}
+		if diff := deep.Equal(reparsed[0].Flags, c.flags); diff != nil {
+			t.Errorf("#%d: reparsed: %v", case_index, diff)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+	"strings"
 )
 
 // TokenKind is the type that defines the kind of token that was found
@@ -261,3 +262,40 @@ func (t *Tokenizer) UntilNot(kind ...TokenKind) ([]Token, Token) {
 	}
 	return tokens, t.Current()
 }
+
+// Comment returns the text of the comment lines directly above the line of the current token
+// lines are joined by a space and the comment stops at the first line that is not a comment, such as an empty line
+func (t *Tokenizer) Comment() string {
+	lines := []string{}
+
+	// find the newline at the end of the line above
+	end := min(t.index, len(t.tokens)) - 1
+	for end >= 0 && t.tokens[end].kind != TokenNewLine {
+		end--
+	}
+
+	for end >= 0 {
+		start := end - 1
+		for start >= 0 && t.tokens[start].kind != TokenNewLine {
+			start--
+		}
+
+		// skip the indentation, the line is only part of the comment if it starts with a #
+		line := t.tokens[start+1 : end]
+		for len(line) > 0 && line[0].kind == TokenEmpty {
+			line = line[1:]
+		}
+		if len(line) == 0 || line[0].kind != TokenComment {
+			break
+		}
+
+		text := ""
+		for _, token := range line[1:] {
+			text += token.String()
+		}
+		lines = append([]string{strings.TrimSpace(text)}, lines...)
+		end = start
+	}
+
+	return strings.Join(lines, " ")
+}
@@ -12,6 +12,11 @@ type PartBinding struct {
 	parts izu.PartList
 }
 
+// NewPartBinding creates a new PartBinding with the parts joined by the separator
+func NewPartBinding(separator string, parts ...izu.Part) *PartBinding {
+	return &PartBinding{izu.NewDefaultPartList(separator, parts...)}
+}
+
 // Info returns ASTBinding and the binding partlist
 func (p *PartBinding) Info() (izu.AST, izu.PartList) {
 	return izu.ASTBinding, p.parts
@@ -35,6 +40,11 @@ type PartSingle struct {
 	parts izu.PartList
 }
 
+// NewPartSingle creates a new PartSingle from the given parts
+func NewPartSingle(parts ...izu.Part) *PartSingle {
+	return &PartSingle{izu.NewDefaultPartList(" + ", parts...)}
+}
+
 // Info returns ASTSingle and the partlist
 func (p *PartSingle) Info() (izu.AST, izu.PartList) {
 	return izu.ASTSingle, p.parts
@@ -58,6 +68,11 @@ type PartMultiple struct {
 	parts izu.PartList
 }
 
+// NewPartMultiple creates a new PartMultiple where every part is one of the paths
+func NewPartMultiple(parts ...izu.Part) *PartMultiple {
+	return &PartMultiple{izu.NewDefaultPartListWithNfixes("{", ",", "}", parts...)}
+}
+
 // Info returns ASTMultiple and the partlist
 func (p *PartMultiple) Info() (izu.AST, izu.PartList) {
 	return izu.ASTMultiple, p.parts
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/meir/izu/pkg/izu"
+)
+
+// keySets are the named sets of keys that can be searched for free key combinations
+var keySets = map[string]func() []string{
+	"letters": func() []string {
+		return strings.Split("abcdefghijklmnopqrstuvwxyz", "")
+	},
+	"digits": func() []string {
+		return strings.Split("0123456789", "")
+	},
+	"function": func() []string {
+		keys := []string{}
+		for i := 1; i <= 12; i++ {
+			keys = append(keys, fmt.Sprintf("F%d", i))
+		}
+		return keys
+	},
+	"keysyms": izu.Keysyms,
+}
+
+// KeySetNames returns the names of all the key sets, sorted by name
+func KeySetNames() []string {
+	names := []string{}
+	for name := range keySets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// KeySet returns the keys in the key set with the given name
+func KeySet(name string) ([]string, error) {
+	set, ok := keySets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown key set '%s', expected one of %s", name, strings.Join(KeySetNames(), ", "))
+	}
+	return set(), nil
+}
+
+// Bound is the set of key combinations used by a list of bindings
+type Bound map[string]bool
+
+// NewBound creates the set of key combinations used by the bindings
+func NewBound(bindings []Binding) Bound {
+	bound := Bound{}
+	for _, binding := range bindings {
+		bound[strings.Join(binding.Keys, "+")] = true
+	}
+	return bound
+}
+
+// Contains returns true if the key is bound together with exactly the given modifiers
+func (bound Bound) Contains(modifiers []string, key string) bool {
+	keys := append(append([]string{}, modifiers...), key)
+	return bound[strings.Join(Normalise(keys), "+")]
+}
+
+// Free returns the keys that are not bound together with exactly the given modifiers, in the order they are given
+func (bound Bound) Free(modifiers []string, keys []string) []string {
+	free := []string{}
+	for _, key := range keys {
+		if !bound.Contains(modifiers, key) && !slices.Contains(free, key) {
+			free = append(free, key)
+		}
+	}
+	return free
+}
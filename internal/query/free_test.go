@@ -0,0 +1,58 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/parser"
+)
+
+func TestFree(t *testing.T) {
+	input := `super + {a,b,c}
+  echo {a,b,c}
+
+super + shift + {a,d}
+  echo {a,d}
+
+super + F{1,2}
+  sway | workspace {1,2}
+
+super + {e,f} | sway[mode=resize]
+  echo {e,f}`
+
+	hotkeys, err := parser.Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		system    string
+		mode      string
+		modifiers string
+		set       string
+		free      []string
+	}{
+		{"sway", "", "super", "letters", []string{"d", "e", "f", "g"}},
+		{"sway", "", "shift+super", "letters", []string{"b", "c", "e", "f"}},
+		{"sway", "", "ctrl", "letters", []string{"a", "b", "c", "d"}},
+		{"sway", "", "super", "function", []string{"F3", "F4", "F5", "F6"}},
+		{"sway", "resize", "super", "letters", []string{"a", "b", "c", "d"}},
+		{"hyprland", "", "super", "function", []string{"F1", "F2", "F3", "F4"}},
+	}
+
+	for i, c := range cases {
+		keys, err := KeySet(c.set)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		free := NewBound(expand(t, hotkeys, c.system, c.mode)).Free(ParseCombo(c.modifiers), keys)
+		if diff := deep.Equal(free[:4], c.free); diff != nil {
+			t.Errorf("#%d: %v", i, diff)
+		}
+	}
+
+	if _, err := KeySet("symbols"); err == nil {
+		t.Errorf("unknown key set did not return an error")
+	}
+}
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"slices"
+	"strings"
+)
+
+// modifiers are the keys that are held down to create a layer of hotkeys
+var modifiers = []string{"super", "ctrl", "alt", "shift", "hyper", "meta", "mod2", "mod3", "mod5"}
+
+// Layer is every binding that is pressed while holding the same modifiers, by the key that is pressed
+type Layer struct {
+	Modifiers []string
+	Keys      map[string][]Binding
+}
+
+// Name returns the modifiers of the layer joined by a +, or "none" if the layer has no modifiers
+func (layer Layer) Name() string {
+	if len(layer.Modifiers) == 0 {
+		return "none"
+	}
+	return strings.Join(layer.Modifiers, "+")
+}
+
+// Modifiers returns the modifiers within the keys, in the order of the list above so layer names read like super+shift
+func Modifiers(keys []string) []string {
+	held := []string{}
+	for _, modifier := range modifiers {
+		if slices.Contains(Normalise(keys), modifier) {
+			held = append(held, modifier)
+		}
+	}
+	return held
+}
+
+// Layers groups the bindings by their modifiers, bindings that press more than one key besides the modifiers are left out
+// the layers are sorted by the amount of modifiers and then by name
+func Layers(bindings []Binding) []Layer {
+	layers := map[string]*Layer{}
+	for _, binding := range bindings {
+		held := Modifiers(binding.Keys)
+		keys := []string{}
+		for _, key := range binding.Keys {
+			if !slices.Contains(modifiers, key) {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) != 1 {
+			continue
+		}
+
+		name := strings.Join(held, "+")
+		layer, ok := layers[name]
+		if !ok {
+			layer = &Layer{held, map[string][]Binding{}}
+			layers[name] = layer
+		}
+		layer.Keys[keys[0]] = append(layer.Keys[keys[0]], binding)
+	}
+
+	output := []Layer{}
+	for _, layer := range layers {
+		output = append(output, *layer)
+	}
+	slices.SortFunc(output, func(a, b Layer) int {
+		if len(a.Modifiers) != len(b.Modifiers) {
+			return len(a.Modifiers) - len(b.Modifiers)
+		}
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return output
+}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/pkg/izu"
+)
+
+// aliases maps the different names of modifiers to the name used in izu configs
+var aliases = map[string]string{
+	"control": "ctrl",
+	"mod1":    "alt",
+	"mod4":    "super",
+	"win":     "super",
+	"logo":    "super",
+}
+
+// Binding is a single key combination of a formatted binding, the command is the command of the combination
+type Binding struct {
+	luaformatter.Binding
+	// Keys are the normalised keys of the combination, sorted so they can be compared
+	Keys []string
+}
+
+// Bindings returns every key combination of the bindings formatted by luaformatter.Formatter.Expand that are in the mode,
+// the mode is empty for the bindings that are not in a mode of the hotkey daemon
+func Bindings(bindings []luaformatter.Binding, mode string) []Binding {
+	output := []Binding{}
+	for _, binding := range bindings {
+		if binding.Mode != mode {
+			continue
+		}
+		for _, combo := range binding.Combos {
+			binding.Command = combo.Command
+			output = append(output, Binding{
+				Binding: binding,
+				Keys:    Normalise(combo.Keys),
+			})
+		}
+	}
+	return output
+}
+
+// Query returns the bindings that fire when pressing the key combination, such as super+shift+s
+func Query(bindings []Binding, combo string) []Binding {
+	keys := Normalise(ParseCombo(combo))
+
+	output := []Binding{}
+	for _, binding := range bindings {
+		if slices.Equal(binding.Keys, keys) {
+			output = append(output, binding)
+		}
+	}
+	return output
+}
+
+// ParseCombo splits a key combination into its keys, both super+s and super + s are accepted
+func ParseCombo(combo string) []string {
+	keys := []string{}
+	for _, key := range strings.Split(combo, "+") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Normalise capitalizes the keys using izu.CapitalizeKey, replaces modifier aliases and sorts the keys
+// so that two combinations with the same keys in a different order are equal
+func Normalise(keys []string) []string {
+	output := []string{}
+	for _, key := range izu.CapitalizeKey([][]string{keys})[0] {
+		if alias, ok := aliases[key]; ok {
+			key = alias
+		}
+		if !slices.Contains(output, key) {
+			output = append(output, key)
+		}
+	}
+	slices.Sort(output)
+	return output
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/meir/izu/internal/luaformatter"
+	"github.com/meir/izu/internal/parser"
+	"github.com/meir/izu/pkg/izu"
+)
+
+// expand formats the hotkeys using the embedded formatter of the system and returns the bindings in the mode
+func expand(t *testing.T, hotkeys []*izu.Hotkey, system, mode string) []Binding {
+	t.Helper()
+	// formatters of the user would replace the embedded formatters
+	t.Setenv(izu.FormatterPathVariable, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	formatter, err := luaformatter.NewFormatter(system)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bindings, _, err := formatter.Expand(hotkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Bindings(bindings, mode)
+}
+
+func TestQuery(t *testing.T) {
+	input := `super + {_,shift +} s
+  save {_,-f}
+
+super + {h,j}
+  sway | focus {left,down}
+  echo {left,down}
+
+super + XF86Audio{Play,Pause}
+  playerctl --{play,pause}
+
+ctrl + q
+  hyprland | killactive,
+
+super + r | sway[mode=resize] hyprland[submap=resize]
+  echo resize`
+
+	hotkeys, err := parser.Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		combo  string
+		system string
+		mode   string
+
+		line      int
+		command   string
+		isDefault bool
+	}{
+		{"super+s", "sway", "", 1, "save ", true},
+		{"Shift + SUPER + S", "sway", "", 1, "save -f", true},
+		{"super+j", "sway", "", 4, "focus down", false},
+		{"super+j", "hyprland", "", 4, "echo down", true},
+		{"mod4+xf86audiopause", "niri", "", 8, "playerctl --pause", true},
+		{"super+xf86audiopause", "sxhkd", "", 8, "playerctl --pause", true},
+		{"super+shift+s", "sxhkd", "", 1, "save -f", true},
+		{"control+q", "hyprland", "", 11, "killactive,", false},
+		{"ctrl+q", "sway", "", 0, "", false},
+		{"super+shift+h", "sway", "", 0, "", false},
+		{"super+r", "sway", "", 0, "", false},
+		{"super+r", "sway", "resize", 14, "echo resize", true},
+		{"super+r", "hyprland", "resize", 14, "echo resize", true},
+		{"super+r", "i3", "", 0, "", false},
+		{"super+r", "i3", "resize", 14, "echo resize", true},
+	}
+
+	for i, c := range cases {
+		bindings := Query(expand(t, hotkeys, c.system, c.mode), c.combo)
+		if c.line == 0 {
+			if len(bindings) != 0 {
+				t.Errorf("#%d: '%s' returned %d bindings, want none", i, c.combo, len(bindings))
+			}
+			continue
+		}
+
+		if len(bindings) != 1 {
+			t.Errorf("#%d: '%s' returned %d bindings, want 1", i, c.combo, len(bindings))
+			continue
+		}
+
+		binding := bindings[0]
+		if diff := deep.Equal(
+			[]any{binding.Hotkey.Line, binding.Command, binding.Default},
+			[]any{c.line, c.command, c.isDefault},
+		); diff != nil {
+			t.Errorf("#%d: %v", i, diff)
+		}
+	}
+}
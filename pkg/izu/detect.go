@@ -0,0 +1,76 @@
+package izu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AutoFormatter is the formatter name that detects the formatter from the running session
+const AutoFormatter = "auto"
+
+// sessionSockets are environment variables that are only set within the session of a specific compositor
+var sessionSockets = []struct {
+	variable string
+	system   string
+}{
+	{"HYPRLAND_INSTANCE_SIGNATURE", "hyprland"},
+	{"NIRI_SOCKET", "niri"},
+	{"SWAYSOCK", "sway"},
+}
+
+// sessionDesktops are environment variables that contain the name of the desktop, these are checked after the sockets
+var sessionDesktops = []string{
+	"XDG_CURRENT_DESKTOP",
+	"XDG_SESSION_DESKTOP",
+	"DESKTOP_SESSION",
+}
+
+// desktopSystems maps the lowercase desktop names to their formatter
+var desktopSystems = map[string]string{
+	"hyprland": "hyprland",
+	"niri":     "niri",
+	"sway":     "sway",
+	"bspwm":    "sxhkd",
+}
+
+// DetectSystem returns the formatter for the session that is currently running, based on its environment variables
+// if nothing matches, the error contains every variable that was checked
+func DetectSystem(getenv func(string) string) (string, error) {
+	checked := []string{}
+
+	for _, socket := range sessionSockets {
+		value := getenv(socket.variable)
+		if value != "" {
+			return socket.system, nil
+		}
+		checked = append(checked, socket.variable+" (unset)")
+	}
+
+	for _, variable := range sessionDesktops {
+		value := getenv(variable)
+		if value == "" {
+			checked = append(checked, variable+" (unset)")
+			continue
+		}
+
+		// XDG_CURRENT_DESKTOP can contain multiple names separated by colons
+		for _, desktop := range strings.Split(value, ":") {
+			if system, ok := desktopSystems[strings.ToLower(strings.TrimSpace(desktop))]; ok {
+				return system, nil
+			}
+		}
+		checked = append(checked, fmt.Sprintf("%s (%q)", variable, value))
+	}
+
+	// sxhkd works with any X11 window manager, so use it when no wayland compositor was found
+	switch value := getenv("XDG_SESSION_TYPE"); value {
+	case "x11":
+		return "sxhkd", nil
+	case "":
+		checked = append(checked, "XDG_SESSION_TYPE (unset)")
+	default:
+		checked = append(checked, fmt.Sprintf("XDG_SESSION_TYPE (%q)", value))
+	}
+
+	return "", fmt.Errorf("could not detect the formatter for this session, checked: %s", strings.Join(checked, ", "))
+}
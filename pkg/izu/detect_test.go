@@ -0,0 +1,43 @@
+package izu
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectSystem(t *testing.T) {
+	cases := []struct {
+		env map[string]string
+
+		system string
+	}{
+		{map[string]string{"HYPRLAND_INSTANCE_SIGNATURE": "abc", "XDG_CURRENT_DESKTOP": "sway"}, "hyprland"},
+		{map[string]string{"NIRI_SOCKET": "/run/user/1000/niri.sock"}, "niri"},
+		{map[string]string{"SWAYSOCK": "/run/user/1000/sway-ipc.sock"}, "sway"},
+		{map[string]string{"XDG_CURRENT_DESKTOP": "Hyprland"}, "hyprland"},
+		{map[string]string{"XDG_CURRENT_DESKTOP": "GNOME", "DESKTOP_SESSION": "niri"}, "niri"},
+		{map[string]string{"XDG_CURRENT_DESKTOP": "ubuntu:sway"}, "sway"},
+		{map[string]string{"XDG_SESSION_DESKTOP": "bspwm"}, "sxhkd"},
+		{map[string]string{"XDG_SESSION_TYPE": "x11"}, "sxhkd"},
+		{map[string]string{"XDG_CURRENT_DESKTOP": "KDE", "XDG_SESSION_TYPE": "wayland"}, ""},
+	}
+
+	for i, c := range cases {
+		system, err := DetectSystem(func(key string) string {
+			return c.env[key]
+		})
+
+		if c.system == "" {
+			if err == nil {
+				t.Errorf("#%d: expected an error, got '%s'", i, system)
+			} else if !strings.Contains(err.Error(), `XDG_CURRENT_DESKTOP ("KDE")`) || !strings.Contains(err.Error(), "SWAYSOCK (unset)") {
+				t.Errorf("#%d: error does not list the checked variables: %v", i, err)
+			}
+			continue
+		}
+
+		if err != nil || system != c.system {
+			t.Errorf("#%d: got '%s' (%v), want '%s'", i, system, err, c.system)
+		}
+	}
+}
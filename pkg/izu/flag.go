@@ -0,0 +1,45 @@
+package izu
+
+import "strings"
+
+// Flag is a single flag given to a system, such as release in sway[release] or mode=resize in sway[mode=resize]
+type Flag struct {
+	Name string
+	// Value is the value of a key/value flag, bare flags have no value
+	Value string
+}
+
+// HasValue returns true if the flag is a key/value flag
+func (flag Flag) HasValue() bool {
+	return flag.Value != ""
+}
+
+// Pair returns the flag as name=value without quoting the value, or only the name for bare flags
+func (flag Flag) Pair() string {
+	if !flag.HasValue() {
+		return flag.Name
+	}
+	return flag.Name + "=" + flag.Value
+}
+
+// String returns the flag as it is written in a config, the value is quoted if it cannot be written bare
+func (flag Flag) String() string {
+	if !flag.HasValue() {
+		return flag.Name
+	}
+	if !strings.ContainsAny(flag.Value, " \t\n+#;{},|[]\"\\") {
+		return flag.Name + "=" + flag.Value
+	}
+	value := strings.ReplaceAll(flag.Value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return flag.Name + `="` + value + `"`
+}
+
+// FlagStrings returns every flag as it is written in a config
+func FlagStrings(flags []Flag) []string {
+	output := []string{}
+	for _, flag := range flags {
+		output = append(output, flag.String())
+	}
+	return output
+}
@@ -4,6 +4,8 @@ import (
 	"embed"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -26,11 +28,123 @@ func GetVersion() string {
 	return strings.TrimSpace(string(version))
 }
 
+// FormatterPathVariable is the environment variable with the directories that are searched for formatters first,
+// separated by colons like PATH
+const FormatterPathVariable = "IZU_FORMATTER_PATH"
+
+// FormatterDirs returns the directories that are searched for formatters before the embedded formatters,
+// these are the directories in IZU_FORMATTER_PATH followed by $XDG_CONFIG_HOME/izu/formatters
+func FormatterDirs(getenv func(string) string) []string {
+	dirs := []string{}
+	for _, dir := range filepath.SplitList(getenv(FormatterPathVariable)) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	if dir := UserFormatterDir(getenv); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// UserFormatterDir returns the formatter directory in the config directory of the user, $XDG_CONFIG_HOME/izu/formatters
+// XDG_CONFIG_HOME defaults to ~/.config, if neither it or HOME is set this is empty
+func UserFormatterDir(getenv func(string) string) string {
+	config := getenv("XDG_CONFIG_HOME")
+	if config == "" {
+		home := getenv("HOME")
+		if home == "" {
+			return ""
+		}
+		config = filepath.Join(home, ".config")
+	}
+	return filepath.Join(config, "izu", "formatters")
+}
+
+// findFormatter returns the path of the formatter with the name in the formatter directories
+// names that are paths, such as ./sway.lua, are never searched for
+func findFormatter(language, system string) (string, bool) {
+	if system == "" || strings.ContainsRune(system, filepath.Separator) || strings.HasSuffix(system, "."+language) {
+		return "", false
+	}
+	for _, dir := range FormatterDirs(os.Getenv) {
+		path := filepath.Join(dir, system+"."+language)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// GetFormatterFile returns the formatter for the system, it is searched for in the formatter directories,
+// then in the embedded formatters and otherwise the system is read as the path to the formatter
 func GetFormatterFile(language, system string) ([]byte, error) {
-	content, err := formatters.ReadFile(fmt.Sprintf("formatters/%s/%s.lua", language, system))
+	if path, ok := findFormatter(language, system); ok {
+		return os.ReadFile(path)
+	}
+	content, err := GetEmbeddedFormatterFile(language, system)
 	if err != nil {
 		// system might be a file path instead of a system name
 		content, err = os.ReadFile(system)
 	}
 	return content, err
 }
+
+// GetEmbeddedFormatterFile returns the embedded formatter for the system, even if the user has a formatter with the same name
+func GetEmbeddedFormatterFile(language, system string) ([]byte, error) {
+	return formatters.ReadFile(fmt.Sprintf("formatters/%s/%s.lua", language, system))
+}
+
+// GetFormatterPath returns the path of the formatter file if it is read from disk instead of being embedded
+func GetFormatterPath(language, system string) (string, bool) {
+	if path, ok := findFormatter(language, system); ok {
+		return path, true
+	}
+	if _, err := GetEmbeddedFormatterFile(language, system); err == nil {
+		return "", false
+	}
+	if _, err := os.Stat(system); err != nil {
+		return "", false
+	}
+	return system, true
+}
+
+// GetFormatterLibrary returns the shared module with the given name that formatters of the language can require,
+// these are the files in the lib directory of the embedded formatters
+func GetFormatterLibrary(language, name string) ([]byte, error) {
+	return formatters.ReadFile(fmt.Sprintf("formatters/%s/lib/%s.lua", language, name))
+}
+
+// GetFormatterNames returns the names of all the embedded formatters for the given language
+func GetFormatterNames(language string) ([]string, error) {
+	entries, err := formatters.ReadDir("formatters/" + language)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".lua"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// GetUserFormatterNames returns the names of the formatters in the formatter directories, sorted by name
+func GetUserFormatterNames(language string) []string {
+	names := []string{}
+	for _, dir := range FormatterDirs(os.Getenv) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if name, ok := strings.CutSuffix(entry.Name(), "."+language); ok && !entry.IsDir() && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
@@ -1,60 +1,44 @@
 local formatter = {}
 local izu = izu
-
-local capitalizations = {
-  ["super"] = "Super",
-  ["shift"] = "Shift",
-  ["ctrl"] = "Ctrl",
+local keys = require("keys")
+
+formatter.name = "hyprland"
+formatter.description = "bind lines for the hyprland config"
+formatter.version = "1.0.0"
+
+formatter.flags = {
+  { name = "l", description = "locked, also works when an input inhibitor is active" },
+  { name = "r", description = "release, runs when the key is released" },
+  { name = "e", description = "repeat, repeats when the key is held" },
+  { name = "n", description = "non-consuming, the key is also passed to the window" },
+  { name = "m", description = "mouse, binds a mouse action such as movewindow" },
+  { name = "t", description = "transparent, cannot be shadowed by other binds" },
+  { name = "i", description = "ignore mods, runs regardless of the held modifiers" },
+  { name = "s", description = "separate, combines keys between modifiers and keys" },
+  { name = "d", description = "has description, the first argument is a description" },
+  { name = "p", description = "bypass, bypasses the app's requests to inhibit keybinds" },
+  { name = "submap", type = "string", description = "bind in the given submap" },
 }
 
-local function replace_capitalizations(keys)
-  local output = {}
-  for _, key in ipairs(keys) do
-    local replacement = capitalizations[key]
-    if replacement ~= nil then
-      table.insert(output, replacement)
-    else
-      table.insert(output, key)
-    end
-  end
-  return output
-end
+-- the flag that binds a hotkey in another submap, so izu query, free and keyboard can tell the bindings apart
+formatter.mode = "submap"
+
+-- command to make hyprland read the generated config again
+formatter.reload = "hyprctl reload"
+
+-- default commands are shell commands that are run by the exec dispatcher, `izu -o shell=false` writes them as they are
+local shell = izu.options.shell ~= "false"
+
+local capitalizations = keys.capitalizations()
 
 -- modifier order for `bind = Super+Shift, exec, echo hellow world
 local modifiers = {
-  "Super",
-  "Shift",
-  "Alt",
-  "Ctrl",
-  "Shift_L",
-  "Shift_R",
-  "Alt_L",
-  "Alt_R",
-  "Ctrl_L",
-  "Ctrl_R",
-  "Super_L",
-  "Super_R",
+  "super",
+  "shift",
+  "alt",
+  "ctrl",
 }
 
-local function order_keys(bind)
-  local mods = {}
-  local keys = {}
-  for _, v in ipairs(bind) do
-    if izu.contains(modifiers, v) then
-      table.insert(mods, v)
-    else
-      if v ~= "" then
-        table.insert(keys, v)
-      end
-    end
-  end
-
-  return {
-    table.concat(mods, "+"),
-    table.concat(keys, "+"),
-  }
-end
-
 -- mousekeys for binds such as mouse:273, mouse:274, etc.
 local mouse_keys = {
   ["mouse_lmb"] = "mouse:272",
@@ -63,16 +47,7 @@ local mouse_keys = {
 }
 
 local function replace_mousekey(key)
-  if mouse_keys[key] then
-    return mouse_keys[key]
-  end
-
-  if key:find("mouse_x") then
-    local x = key:match("mouse_x(%d)")
-    return "mouse:" .. (274 + tonumber(x))
-  end
-
-  return key
+  return keys.mouse(key, mouse_keys)
 end
 
 -- flags for binds, such as bindl, bindr, bindm, etc.
@@ -91,7 +66,7 @@ local bindflags = {
 
 local function get_flags(flags)
   local bindflag = ""
-  for _, v in pairs(flags) do
+  for _, v in ipairs(flags) do
     if izu.contains(bindflags, v) then
       bindflag = bindflag .. v
     end
@@ -99,30 +74,70 @@ local function get_flags(flags)
   return bindflag
 end
 
+-- submaps are given as flags such as submap=resize, the older submap-resize is still accepted
+local function get_submap(flags)
+  if flags.submap ~= nil then
+    return flags.submap
+  end
+  for _, v in ipairs(flags) do
+    local submap = v:match("^submap%-(.+)$")
+    if submap ~= nil then
+      return submap
+    end
+  end
+  return nil
+end
+
 -- Formatter functions
 
 function formatter.hotkey (args)
   local bindflag = get_flags(args.flags)
-  return "bind" .. bindflag .. " = " .. table.concat(args.value, ", ")
+  local value = args.value
+  if args.default and shell then
+    value = {args.value[1], "exec", args.value[2]}
+  end
+  local bind = "bind" .. bindflag .. " = " .. table.concat(value, ", ")
+
+  -- every bind in a submap gets its own submap section, hyprland allows entering the same submap multiple times
+  local submap = get_submap(args.flags)
+  if submap ~= nil then
+    return {"submap = " .. submap, bind, "submap = reset"}
+  end
+  return bind
+end
+
+-- dispatch returns the command line that runs a command from a shell, the `dispatcher, params` of the config
+-- are given to hyprctl dispatch as separate arguments
+function formatter.dispatch (args)
+  if args.default and shell then
+    return {"sh", "-c", args.value}
+  end
+
+  local dispatcher, params = args.value:match("^([^,]*),(.*)$")
+  if dispatcher == nil then
+    return {"hyprctl", "dispatch", izu.trim(args.value)}
+  end
+  local output = {"hyprctl", "dispatch", izu.trim(dispatcher)}
+  if izu.trim(params) ~= "" then
+    table.insert(output, izu.trim(params))
+  end
+  return output
 end
 
 function formatter.binding (args)
   if args.state == 1 then
-    return table.concat(order_keys(replace_capitalizations(args.value)), ", ")
+    local mods, pressed = izu.split_modifiers(args.value, modifiers)
+    return izu.join(izu.map_keys(mods, capitalizations), "+") .. ", " .. izu.join(pressed, "+")
   end
   return table.concat(args.value, "")
 end
 
 function formatter.multiple (args)
-  return args.value
+  return keys.multiple(args)
 end
 
 function formatter.single (args)
-  local value = table.concat(args.value, "")
-  if value == "_" then
-    return {}
-  end
-  return {replace_mousekey(value)}
+  return keys.single(args, replace_mousekey)
 end
 
 function formatter.string (args)
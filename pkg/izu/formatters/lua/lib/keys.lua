@@ -0,0 +1,76 @@
+-- shared key handling for the embedded formatters, custom formatters can use it with require("keys")
+local keys = {}
+local izu = izu
+
+-- capitalizations returns the capitalized modifier names most compositors use, with the extra names added to them
+function keys.capitalizations(extra)
+  local output = {
+    ["super"] = "Super",
+    ["shift"] = "Shift",
+    ["ctrl"] = "Ctrl",
+    ["alt"] = "Alt",
+  }
+  for key, value in pairs(extra or {}) do
+    output[key] = value
+  end
+  return output
+end
+
+-- mouse returns the name of a mouse key such as mouse_lmb using the mapping,
+-- the extra buttons such as mouse_x1 are written as mouse:<button code>
+function keys.mouse(key, mapping)
+  if mapping[key] then
+    return mapping[key]
+  end
+
+  if key:find("mouse_x") then
+    local x = key:match("mouse_x(%d)")
+    return "mouse:" .. (274 + tonumber(x))
+  end
+
+  return key
+end
+
+-- single joins the value of a single key and leaves out the _ of {_,shift}, replace is an optional function
+-- that is called with the key, such as to replace mouse keys
+function keys.single(args, replace)
+  local value = table.concat(args.value, "")
+  if value == "_" then
+    return {}
+  end
+  if replace ~= nil then
+    value = replace(value)
+  end
+  return {value}
+end
+
+-- multiple returns the values of a multiple, the _ in a command such as `save {_,-f}` is left out like in sxhkd
+function keys.multiple(args)
+  local output = {}
+  for _, value in ipairs(args.value) do
+    if value == "_" then
+      value = ""
+    end
+    table.insert(output, value)
+  end
+  return output
+end
+
+-- binding joins the keys of a binding with the separator, leaving out the keys that were skipped
+-- the modifiers come first in the given order and are replaced using the capitalizations if they are given
+function keys.binding(args, separator, order, capitalizations)
+  if args.state ~= 1 then
+    return table.concat(args.value, "")
+  end
+
+  local value = args.value
+  if order ~= nil then
+    value = izu.order_modifiers(value, order)
+  end
+  if capitalizations ~= nil then
+    value = izu.map_keys(value, capitalizations)
+  end
+  return izu.join(value, separator)
+end
+
+return keys
@@ -1,37 +1,35 @@
 local formatter = {}
 local izu = izu
+local keys = require("keys")
 
-local capitalizations = {
-	["super"] = "Super",
-	["shift"] = "Shift",
-	["ctrl"] = "Ctrl",
+formatter.name = "niri"
+formatter.description = "binds section for the niri config"
+formatter.version = "1.0.0"
+
+formatter.flags = {
+	{ name = "allow-when-locked", description = "also run the spawn action when the screen is locked" },
+	{ name = "allow-inhibiting", description = "allow the binding to be inhibited, written as allow-inhibiting=true" },
+	{ name = "no-allow-inhibiting", description = "the binding cannot be inhibited by applications" },
+	{ name = "repeat", description = "repeat the action when the key is held" },
+	{ name = "no-repeat", description = "do not repeat the action when the key is held" },
+	{ name = "cooldown-ms", type = "number", description = "minimum time in milliseconds between runs" },
+	{ name = "hotkey-overlay-title", type = "string", description = "title of the bind in the hotkey overlay" },
 }
 
-local function replace_capitalizations(keys)
-	local output = {}
-	for _, key in ipairs(keys) do
-		local replacement = capitalizations[key]
-		if replacement ~= nil then
-			table.insert(output, replacement)
-		else
-			-- if length == 1, uppercase it
-			if #key == 1 then
-				table.insert(output, izu.uppercase(key))
-			else
-				table.insert(output, key)
-			end
-		end
-	end
-	return output
-end
+-- niri reloads its config by itself when it changes, so there is no reload command
+
+-- default commands are shell commands that are run using spawn, `izu -o shell=false` writes them as they are
+local shell = izu.options.shell ~= "false"
 
--- modifier order for `bind = Super+Shift, exec, echo hellow world
+local capitalizations = keys.capitalizations({ ["mod"] = "Mod" })
+
+-- modifier order for `Mod+Shift+T { spawn "foot"; }`
 local modifiers = {
-	"Super",
-	"Shift",
-	"Alt",
-	"Ctrl",
-	"Mod",
+	"mod",
+	"super",
+	"ctrl",
+	"shift",
+	"alt",
 }
 
 -- mousekeys for binds such as mouse:273, mouse:274, etc.
@@ -42,23 +40,64 @@ local mouse_keys = {
 }
 
 local function replace_mousekey(key)
-	if mouse_keys[key] then
-		return mouse_keys[key]
-	end
+	return keys.mouse(key, mouse_keys)
+end
 
-	if key:find("mouse_x") then
-		local x = key:match("mouse_x(%d)")
-		return "mouse:" .. (274 + tonumber(x))
-	end
+local function quote(str)
+	local escaped = str:gsub("\\", "\\\\"):gsub('"', '\\"')
+	return '"' .. escaped .. '"'
+end
 
-	return key
+-- flags are written as properties of the bind, allow-when-locked becomes allow-when-locked=true,
+-- no-repeat becomes repeat=false, cooldown-ms=150 stays cooldown-ms=150 and other values are quoted
+-- such as hotkey-overlay-title="Open a Terminal", the older cooldown-ms-150 is written as cooldown-ms=150 as well
+local function get_properties(flags)
+	local output = {}
+	for _, v in ipairs(flags) do
+		local key = v:match("^([^=]+)=")
+		local name, number = v:match("^(.-)%-(%d+)$")
+		if key ~= nil then
+			local value = flags[key]
+			if value:match("^%d+$") == nil then
+				value = quote(value)
+			end
+			table.insert(output, key .. "=" .. value)
+		elseif name ~= nil then
+			table.insert(output, name .. "=" .. number)
+		elseif v:sub(1, 3) == "no-" then
+			table.insert(output, v:sub(4) .. "=false")
+		else
+			table.insert(output, v .. "=true")
+		end
+	end
+	return output
 end
 
-local function filter_empty_strings(tbl)
+-- arguments splits an action such as `spawn "foot" "-e" "htop";` into its name and unquoted arguments
+local function arguments(action)
 	local output = {}
-	for _, v in ipairs(tbl) do
-		if v ~= "" then
-			table.insert(output, v)
+	action = izu.trim(action, "; \t\n")
+	local i = 1
+	while i <= #action do
+		local c = action:sub(i, i)
+		if c:match("%s") then
+			i = i + 1
+		elseif c == '"' then
+			local value = ""
+			i = i + 1
+			while i <= #action and action:sub(i, i) ~= '"' do
+				if action:sub(i, i) == "\\" then
+					i = i + 1
+				end
+				value = value .. action:sub(i, i)
+				i = i + 1
+			end
+			table.insert(output, value)
+			i = i + 1
+		else
+			local value = action:match("^%S+", i)
+			table.insert(output, value)
+			i = i + #value
 		end
 	end
 	return output
@@ -66,27 +105,61 @@ end
 
 -- Formatter functions
 
+-- dispatch returns the command line that runs an action from a shell, spawn actions are run directly
+-- and the other actions are run using niri msg action
+function formatter.dispatch(args)
+	if args.default and shell then
+		return { "sh", "-c", args.value }
+	end
+
+	local action = arguments(args.value)
+	if action[1] == "spawn" then
+		table.remove(action, 1)
+		return action
+	elseif action[1] == "spawn-sh" then
+		return { "sh", "-c", action[2] }
+	end
+
+	local output = { "niri", "msg", "action" }
+	for _, v in ipairs(action) do
+		table.insert(output, v)
+	end
+	return output
+end
+
 function formatter.hotkey(args)
-	return args.value[1] .. " { " .. args.value[2] .. " }"
+	local bind = args.value[1]
+	local properties = get_properties(args.flags)
+	if #properties > 0 then
+		bind = bind .. " " .. table.concat(properties, " ")
+	end
+	local command = args.value[2]
+	if args.default and shell then
+		command = 'spawn "sh" "-c" ' .. quote(command) .. ";"
+	end
+	return bind .. " { " .. command .. " }"
 end
 
 function formatter.binding(args)
 	if args.state == 1 then
-		return table.concat(filter_empty_strings(replace_capitalizations(args.value)), "+")
+		local names = izu.map_keys(izu.order_modifiers(args.value, modifiers), capitalizations)
+		-- single letters are written in uppercase
+		for i, key in ipairs(names) do
+			if #key == 1 then
+				names[i] = izu.uppercase(key)
+			end
+		end
+		return izu.join(names, "+")
 	end
 	return table.concat(args.value, "")
 end
 
 function formatter.multiple(args)
-	return args.value
+	return keys.multiple(args)
 end
 
 function formatter.single(args)
-	local value = table.concat(args.value, "")
-	if value == "_" then
-		return {}
-	end
-	return { replace_mousekey(value) }
+	return keys.single(args, replace_mousekey)
 end
 
 function formatter.string(args)
@@ -1,23 +1,110 @@
 local formatter = {}
 local izu = izu
+local keys = require("keys")
+
+formatter.name = "sway"
+formatter.description = "bindsym lines for the sway and i3 config"
+formatter.version = "1.0.0"
+formatter.aliases = {"i3"}
+
+formatter.flags = {
+  { name = "release", description = "run the command when the key is released" },
+  { name = "locked", description = "also run the command when the screen is locked" },
+  { name = "to-code", description = "translate the keysyms to keycodes of the first layout" },
+  { name = "whole-window", description = "mouse bindings work on the whole window" },
+  { name = "border", description = "mouse bindings work on the window border" },
+  { name = "exclude-titlebar", description = "mouse bindings do not work on the titlebar" },
+  { name = "inhibited", description = "also run the command when the keyboard shortcuts are inhibited" },
+  { name = "no-warn", description = "do not warn when the binding overrides another binding" },
+  { name = "no-repeat", description = "do not repeat the command when the key is held" },
+  { name = "bindcode", description = "use bindcode instead of bindsym" },
+  { name = "input-device", type = "string", description = "only run the command for the given input device" },
+  { name = "mode", type = "string", description = "bind in the given mode" },
+}
+
+-- the flag that binds a hotkey in another mode, so izu query, free and keyboard can tell the bindings apart
+formatter.mode = "mode"
+
+-- command to make sway read the generated config again
+formatter.reload = "swaymsg reload"
+
+-- default commands are shell commands that are run using exec, `izu -o shell=false` writes them as they are
+-- the exec option changes the command they are run with, such as `izu -o exec="exec --no-startup-id"` for i3
+local shell = izu.options.shell ~= "false"
+local exec = izu.options.exec or "exec"
+
+-- bare flags that are passed as an option to bindsym, such as --release
+local options = {
+  "release",
+  "locked",
+  "to-code",
+  "whole-window",
+  "border",
+  "exclude-titlebar",
+  "inhibited",
+  "no-warn",
+  "no-repeat",
+}
+
+-- get_bind returns the bind command with its options and the mode it should be in
+local function get_bind(flags)
+  local bind = {"bindsym"}
+  for _, v in ipairs(flags) do
+    if v == "bindcode" then
+      bind[1] = "bindcode"
+    elseif izu.contains(options, v) then
+      table.insert(bind, "--" .. v)
+    end
+  end
+  if flags["input-device"] ~= nil then
+    table.insert(bind, "--input-device=" .. flags["input-device"])
+  end
+
+  -- the mode used to be written as mode-<name>, which is still accepted
+  local mode = flags.mode
+  if mode == nil then
+    for _, v in ipairs(flags) do
+      if v:match("^mode%-.+$") then
+        mode = v:sub(6)
+      end
+    end
+  end
+  return table.concat(bind, " "), mode
+end
 
 function formatter.hotkey (args)
-  return "bindsym " .. table.concat(args.value, ", exec, ")
+  local bind, mode = get_bind(args.flags)
+  local command = args.value[2]
+  if args.default and shell then
+    command = exec .. " " .. command
+  end
+
+  local line = bind .. " " .. args.value[1] .. " " .. command
+  -- sway allows the bindings of a mode to be written on a single line instead of a block
+  if mode ~= nil then
+    line = "mode \"" .. mode .. "\" " .. line
+  end
+  return line
 end
 
-function formatter.binding (args)
-  if args.state == 1 then
-    return table.concat(args.value, "+")
+-- dispatch returns the command line that runs a command from a shell, sway commands are run using swaymsg
+function formatter.dispatch (args)
+  if args.default and shell then
+    return {"sh", "-c", args.value}
   end
-  return table.concat(args.value, "")
+  return {"swaymsg", args.value}
+end
+
+function formatter.binding (args)
+  return keys.binding(args, "+")
 end
 
 function formatter.multiple (args)
-  return args.value
+  return keys.multiple(args)
 end
 
 function formatter.single (args)
-  return table.concat(args.value, "")
+  return keys.single(args)
 end
 
 function formatter.string (args)
@@ -1,6 +1,16 @@
 local formatter = {}
 local izu = izu
 
+formatter.name = "sxhkd"
+formatter.description = "hotkeys for the sxhkd config, using the same syntax as izu"
+formatter.version = "1.0.0"
+
+-- sxhkd has no options for its hotkeys, so no flags are accepted
+formatter.flags = {}
+
+-- command to make sxhkd read the generated config again
+formatter.reload = "pkill -USR1 -x sxhkd"
+
 function formatter.hotkey (args)
   return table.concat(args.value, "\n  ")
 end
@@ -0,0 +1,84 @@
+package izu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestFormatterDirs(t *testing.T) {
+	cases := []struct {
+		env  map[string]string
+		dirs []string
+	}{
+		{map[string]string{"HOME": "/home/izu"}, []string{"/home/izu/.config/izu/formatters"}},
+		{map[string]string{"HOME": "/home/izu", "XDG_CONFIG_HOME": "/config"}, []string{"/config/izu/formatters"}},
+		{map[string]string{"IZU_FORMATTER_PATH": "/a::/b", "XDG_CONFIG_HOME": "/config"}, []string{"/a", "/b", "/config/izu/formatters"}},
+		{map[string]string{}, []string{}},
+	}
+
+	for i, c := range cases {
+		dirs := FormatterDirs(func(key string) string {
+			return c.env[key]
+		})
+		if diff := deep.Equal(dirs, c.dirs); diff != nil {
+			t.Errorf("#%d: %v", i, diff)
+		}
+	}
+}
+
+func TestGetFormatterFile(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	t.Setenv("IZU_FORMATTER_PATH", first)
+	t.Setenv("XDG_CONFIG_HOME", second)
+
+	files := map[string]string{
+		filepath.Join(first, "sway.lua"):                       "first sway",
+		filepath.Join(second, "izu", "formatters", "sway.lua"): "second sway",
+		filepath.Join(second, "izu", "formatters", "mine.lua"): "second mine",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	embedded, err := GetEmbeddedFormatterFile("lua", "niri")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		system  string
+		content string
+		path    string
+	}{
+		{"sway", "first sway", filepath.Join(first, "sway.lua")},
+		{"mine", "second mine", filepath.Join(second, "izu", "formatters", "mine.lua")},
+		{"niri", string(embedded), ""},
+		{filepath.Join(second, "izu", "formatters", "sway.lua"), "second sway", filepath.Join(second, "izu", "formatters", "sway.lua")},
+	}
+
+	for i, c := range cases {
+		content, err := GetFormatterFile("lua", c.system)
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", i, err)
+			continue
+		}
+		if string(content) != c.content {
+			t.Errorf("#%d: read '%s', want '%s'", i, content, c.content)
+		}
+		if path, _ := GetFormatterPath("lua", c.system); path != c.path {
+			t.Errorf("#%d: path is '%s', want '%s'", i, path, c.path)
+		}
+	}
+
+	if diff := deep.Equal(GetUserFormatterNames("lua"), []string{"mine", "sway"}); diff != nil {
+		t.Error(diff)
+	}
+}
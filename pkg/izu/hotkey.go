@@ -2,40 +2,74 @@ package izu
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
 // Hotkey is the type that defines a single hotkey
 type Hotkey struct {
 	Binding Part
-	Flags   map[string][]string
+	Flags   map[string][]Flag
 	Command map[string]Part
+	// Line is the line in the config the hotkey was defined on
+	Line int
+	// Description is the comment directly above the hotkey in the config
+	Description string
 }
 
 func (hotkey Hotkey) String() string {
 	binding := hotkey.Binding.String()
 
+	// sort the systems so that printing the same hotkey always gives the same output
+	systems := []string{}
+	for system := range hotkey.Flags {
+		systems = append(systems, system)
+	}
+	slices.Sort(systems)
+
 	flaglist := []string{}
-	for flag, values := range hotkey.Flags {
-		flaglist = append(flaglist, fmt.Sprintf("%s[%s]", flag, strings.Join(values, " ")))
+	for _, flag := range systems {
+		flaglist = append(flaglist, fmt.Sprintf("%s[%s]", flag, strings.Join(FlagStrings(hotkey.Flags[flag]), " ")))
 	}
 	flags := strings.Join(flaglist, " ")
 	if flags != "" {
 		flags = " | " + flags
 	}
 
+	// the default command is printed last, like the fallback it is
+	systems = []string{}
+	for system := range hotkey.Command {
+		systems = append(systems, system)
+	}
+	slices.SortFunc(systems, func(a, b string) int {
+		switch {
+		case a == b:
+			return 0
+		case a == "default":
+			return 1
+		case b == "default":
+			return -1
+		}
+		return strings.Compare(a, b)
+	})
+
 	commandlist := []string{}
-	for command, parts := range hotkey.Command {
+	for _, command := range systems {
 		pre := ""
 		if command != "default" {
 			pre = fmt.Sprintf("%s | ", command)
 		}
-		commandlist = append(commandlist, fmt.Sprintf("  %s%s", pre, parts.String()))
+		commandlist = append(commandlist, fmt.Sprintf("  %s%s", pre, hotkey.Command[command].String()))
 	}
 	commands := strings.Join(commandlist, "\n")
 	if commands != "" {
 		commands = "\n" + commands
 	}
 
-	return fmt.Sprintf("%s%s%s\n", binding, flags, commands)
+	description := ""
+	if hotkey.Description != "" {
+		description = "# " + hotkey.Description + "\n"
+	}
+
+	return fmt.Sprintf("%s%s%s%s\n", description, binding, flags, commands)
 }
@@ -0,0 +1,31 @@
+package izu_test
+
+import (
+	"testing"
+
+	"github.com/meir/izu/internal/parser"
+)
+
+func TestHotkeyString(t *testing.T) {
+	cases := []struct {
+		input  string
+		output string
+	}{
+		{"super + a\n  foot", "super + a\n  foot\n"},
+		{
+			"super + a | sway[locked] hyprland[l]\n  niri | spawn \"foot\";\n  foot\n  hyprland | exec, foot",
+			"super + a | hyprland[l] sway[locked]\n  hyprland | exec, foot\n  niri | spawn \"foot\";\n  foot\n",
+		},
+	}
+
+	for i, c := range cases {
+		hotkeys, err := parser.Parse([]byte(c.input))
+		if err != nil {
+			t.Errorf("#%d: returned error: %v", i, err)
+			continue
+		}
+		if output := hotkeys[0].String(); output != c.output {
+			t.Errorf("#%d: got %q, want %q", i, output, c.output)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package izu
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 var keys = map[string]string{}
 
@@ -25,3 +28,19 @@ func CapitalizeKey(binding [][]string) [][]string {
 
 	return output
 }
+
+// Keysym returns the name of the xkb key with the correct capitalization, the name is matched case insensitive
+func Keysym(name string) (string, bool) {
+	key, ok := keys[strings.ToLower(name)]
+	return key, ok
+}
+
+// Keysyms returns the names of all the xkb keys in the generated map, sorted by name
+func Keysyms() []string {
+	output := make([]string, 0, len(keys))
+	for _, key := range keys {
+		output = append(output, key)
+	}
+	slices.Sort(output)
+	return output
+}
@@ -0,0 +1,17 @@
+package izu
+
+import (
+	"regexp"
+	"strings"
+)
+
+// shellSafe matches arguments that do not need to be quoted in a shell
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9@%+=:,./_-]+$`)
+
+// ShellQuote quotes the argument in single quotes if it contains any characters that the shell would interpret
+func ShellQuote(arg string) string {
+	if shellSafe.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}